@@ -0,0 +1,160 @@
+/*
+Package auth implements the OAuth2 flows of the OSF accounts server, so that
+applications can act on behalf of OSF users without handling their personal
+access tokens.
+
+Web applications redirect users to the URL of a Flow, keep its State and
+Verifier in their session, and exchange the code they get back:
+
+	cfg := auth.NewConfig(osf.EnvironmentProduction, clientID, clientSecret, redirectURL, auth.ScopeFullRead)
+
+	flow, err := cfg.NewFlow()
+	// Keep flow.State and flow.Verifier, and redirect to flow.AuthCodeURL().
+
+	// Once redirected back to redirectURL:
+	flow = &auth.Flow{Config: cfg, State: state, Verifier: verifier}
+	token, err := flow.Exchange(ctx, r.URL.Query().Get("state"), r.URL.Query().Get("code"))
+
+	client := osf.NewClient(cfg.Client(ctx, token))
+
+Command-line tools can use LoginLoopback instead, which receives the code on a
+local server.
+
+NewClient returns a client authenticated with the first credentials found by
+the default credential chain: an explicit token, the OSF_API_TOKEN environment
+variable, a profile of the config file, then an EncryptedFileStore.
+*/
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"golang.org/x/oauth2"
+)
+
+// Scopes which can be requested. See ScopesService.ListScopes for all of them.
+const (
+	ScopeFullRead  = "osf.full_read"
+	ScopeFullWrite = "osf.full_write"
+)
+
+// Endpoint returns the OAuth2 endpoint of the accounts server of env.
+func Endpoint(env *osf.Environment) oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:   env.AccountsURL + "oauth2/authorize",
+		TokenURL:  env.AccountsURL + "oauth2/token",
+		AuthStyle: oauth2.AuthStyleInParams,
+	}
+}
+
+// Config is the OAuth2 configuration of an application registered on an OSF
+// environment, see ApplicationsService.
+type Config struct {
+	*oauth2.Config
+
+	// RevokeURL is the endpoint revoking tokens.
+	RevokeURL string
+}
+
+// NewConfig returns the configuration of the application identified by
+// clientID on env. clientSecret may be empty for public clients, which must
+// then rely on PKCE.
+func NewConfig(env *osf.Environment, clientID, clientSecret, redirectURL string, scopes ...string) *Config {
+	return &Config{
+		Config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Scopes:       scopes,
+			Endpoint:     Endpoint(env),
+		},
+		RevokeURL: env.AccountsURL + "oauth2/revoke",
+	}
+}
+
+// Flow is an authorization code flow with PKCE. State and Verifier must be
+// kept between the redirection to AuthCodeURL and the call to Exchange.
+type Flow struct {
+	Config *Config
+
+	State    string
+	Verifier string
+}
+
+// NewFlow starts an authorization code flow with a random state and PKCE verifier.
+func (c *Config) NewFlow() (*Flow, error) {
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := NewVerifier()
+	if err != nil {
+		return nil, err
+	}
+	return &Flow{Config: c, State: state, Verifier: verifier}, nil
+}
+
+// AuthCodeURL returns the URL of the consent page to redirect the user to. It
+// asks for a refresh token, so that access survives the expiry of the access token.
+func (f *Flow) AuthCodeURL(opts ...oauth2.AuthCodeOption) string {
+	opts = append([]oauth2.AuthCodeOption{
+		oauth2.AccessTypeOffline,
+		oauth2.SetAuthURLParam("approval_prompt", "force"),
+		oauth2.SetAuthURLParam("code_challenge", S256Challenge(f.Verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	}, opts...)
+	return f.Config.AuthCodeURL(f.State, opts...)
+}
+
+// Exchange checks the state the user was redirected back with and exchanges
+// the authorization code for a token.
+func (f *Flow) Exchange(ctx context.Context, state, code string) (*oauth2.Token, error) {
+	if state != f.State {
+		return nil, fmt.Errorf("state mismatch: the authorization response is not for this flow")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("missing authorization code")
+	}
+	return f.Config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", f.Verifier))
+}
+
+// Refresh returns a new access token obtained with refreshToken.
+func (c *Config) Refresh(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	return c.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+}
+
+// Revoke revokes an access or refresh token. As with the other methods, the
+// HTTP client can be set with the oauth2.HTTPClient context key.
+func (c *Config) Revoke(ctx context.Context, token string) error {
+	form := url.Values{"token": {token}, "client_id": {c.ClientID}}
+	if c.ClientSecret != "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.RevokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	hc, ok := ctx.Value(oauth2.HTTPClient).(*http.Client)
+	if !ok || hc == nil {
+		hc = http.DefaultClient
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("cannot revoke token: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAccounts is a stand-in for the OSF accounts server.
+type fakeAccounts struct {
+	t *testing.T
+
+	mu          sync.Mutex
+	challenge   string
+	redirectURI string
+	revoked     []string
+}
+
+func setupAccounts(t *testing.T) (*osf.Environment, *fakeAccounts, func()) {
+	accounts := &fakeAccounts{t: t}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/authorize", accounts.authorize)
+	mux.HandleFunc("/oauth2/token", accounts.token)
+	mux.HandleFunc("/oauth2/revoke", accounts.revoke)
+	server := httptest.NewServer(mux)
+
+	env := &osf.Environment{Name: "fake", BaseURL: server.URL + "/v2/", AccountsURL: server.URL + "/"}
+	return env, accounts, server.Close
+}
+
+func (a *fakeAccounts) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	assert.Equal(a.t, "client", q.Get("client_id"))
+	assert.Equal(a.t, "code", q.Get("response_type"))
+	assert.Equal(a.t, "offline", q.Get("access_type"))
+	assert.Equal(a.t, "S256", q.Get("code_challenge_method"))
+
+	a.mu.Lock()
+	a.challenge = q.Get("code_challenge")
+	a.redirectURI = q.Get("redirect_uri")
+	a.mu.Unlock()
+
+	redirect, _ := url.Parse(q.Get("redirect_uri"))
+	redirect.RawQuery = url.Values{"code": {"c0de"}, "state": {q.Get("state")}}.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (a *fakeAccounts) token(w http.ResponseWriter, r *http.Request) {
+	assert.NoError(a.t, r.ParseForm())
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var access string
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		if r.PostForm.Get("code") != "c0de" ||
+			S256Challenge(r.PostForm.Get("code_verifier")) != a.challenge ||
+			r.PostForm.Get("redirect_uri") != a.redirectURI {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		access = "access1"
+	case "refresh_token":
+		assert.Equal(a.t, "refresh1", r.PostForm.Get("refresh_token"))
+		access = "access2"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token":  access,
+		"refresh_token": "refresh1",
+		"token_type":    "Bearer",
+		"expires_in":    3600,
+	})
+}
+
+func (a *fakeAccounts) revoke(w http.ResponseWriter, r *http.Request) {
+	assert.NoError(a.t, r.ParseForm())
+	a.mu.Lock()
+	a.revoked = append(a.revoked, r.PostForm.Get("token"))
+	a.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestFlow(t *testing.T) {
+	env, accounts, teardown := setupAccounts(t)
+	defer teardown()
+
+	ctx := context.Background()
+	cfg := NewConfig(env, "client", "secret", "https://app.example.com/callback", ScopeFullRead)
+
+	flow, err := cfg.NewFlow()
+	if err != nil {
+		t.Fatalf("NewFlow returned error: %v", err)
+	}
+
+	// Don't follow the redirection to the app, only read where it goes.
+	hc := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := hc.Get(flow.AuthCodeURL())
+	if err != nil {
+		t.Fatalf("GET consent page returned error: %v", err)
+	}
+	resp.Body.Close()
+	callback, _ := url.Parse(resp.Header.Get("Location"))
+
+	_, err = flow.Exchange(ctx, "forged", callback.Query().Get("code"))
+	assert.Error(t, err)
+
+	// The app resumes the flow from its session.
+	flow = &Flow{Config: cfg, State: flow.State, Verifier: flow.Verifier}
+	token, err := flow.Exchange(ctx, callback.Query().Get("state"), callback.Query().Get("code"))
+	if err != nil {
+		t.Fatalf("Flow.Exchange returned error: %v", err)
+	}
+	assert.Equal(t, "access1", token.AccessToken)
+	assert.Equal(t, "refresh1", token.RefreshToken)
+
+	token, err = cfg.Refresh(ctx, token.RefreshToken)
+	if err != nil {
+		t.Fatalf("Config.Refresh returned error: %v", err)
+	}
+	assert.Equal(t, "access2", token.AccessToken)
+
+	if err := cfg.Revoke(ctx, token.AccessToken); err != nil {
+		t.Fatalf("Config.Revoke returned error: %v", err)
+	}
+	assert.Equal(t, []string{"access2"}, accounts.revoked)
+}
+
+func TestFlow_WrongVerifier(t *testing.T) {
+	env, _, teardown := setupAccounts(t)
+	defer teardown()
+
+	cfg := NewConfig(env, "client", "", "https://app.example.com/callback")
+	flow, _ := cfg.NewFlow()
+
+	hc := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := hc.Get(flow.AuthCodeURL())
+	if err != nil {
+		t.Fatalf("GET consent page returned error: %v", err)
+	}
+	resp.Body.Close()
+	callback, _ := url.Parse(resp.Header.Get("Location"))
+
+	flow.Verifier, _ = NewVerifier()
+	_, err = flow.Exchange(context.Background(), callback.Query().Get("state"), callback.Query().Get("code"))
+	assert.Error(t, err)
+}
+
+func TestConfig_LoginLoopback(t *testing.T) {
+	env, _, teardown := setupAccounts(t)
+	defer teardown()
+
+	cfg := NewConfig(env, "client", "secret", "http://127.0.0.1:0/callback", ScopeFullRead)
+
+	// The "browser" follows the redirections up to the loopback server.
+	token, err := cfg.LoginLoopback(context.Background(), func(authURL string) error {
+		go func() {
+			resp, err := http.Get(authURL)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Config.LoginLoopback returned error: %v", err)
+	}
+	assert.Equal(t, "access1", token.AccessToken)
+}
+
+func TestConfig_LoginLoopback_Localhost(t *testing.T) {
+	env, _, teardown := setupAccounts(t)
+	defer teardown()
+
+	cfg := NewConfig(env, "client", "secret", "http://localhost:0/callback", ScopeFullRead)
+
+	token, err := cfg.LoginLoopback(context.Background(), func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		redirect, _ := url.Parse(u.Query().Get("redirect_uri"))
+		assert.Equal(t, "localhost", redirect.Hostname())
+		assert.NotEqual(t, "0", redirect.Port())
+
+		// A response with another state is rejected.
+		forged := *redirect
+		forged.RawQuery = url.Values{"state": {"forged"}, "code": {"code"}}.Encode()
+		resp, err := http.Get(forged.String())
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		}
+
+		go func() {
+			resp, err := http.Get(authURL)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Config.LoginLoopback returned error: %v", err)
+	}
+	assert.Equal(t, "access1", token.AccessToken)
+}
+
+func TestConfig_LoginLoopback_NotLoopback(t *testing.T) {
+	cfg := NewConfig(osf.EnvironmentTest, "client", "", "https://app.example.com/callback")
+	_, err := cfg.LoginLoopback(context.Background(), func(string) error { return nil })
+	assert.Error(t, err)
+}
@@ -0,0 +1,404 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"golang.org/x/oauth2"
+)
+
+// Environment variables read by the default credential chain.
+const (
+	EnvToken       = "OSF_API_TOKEN"
+	EnvEnvironment = "OSF_ENVIRONMENT"
+	EnvProfile     = "OSF_PROFILE"
+	EnvConfigFile  = "OSF_CONFIG_FILE"
+	EnvPassphrase  = "OSF_CREDENTIALS_PASSPHRASE"
+)
+
+// DefaultProfile is the profile used when none is given.
+const DefaultProfile = "default"
+
+// ErrNoCredentials is returned by a CredentialsProvider which has no credentials.
+var ErrNoCredentials = errors.New("no OSF credentials found")
+
+// Credentials are an access token along with the environment it is valid for.
+type Credentials struct {
+	Environment *osf.Environment
+	Token       *oauth2.Token
+
+	// ClientID and ClientSecret identify the application the token was issued
+	// to, if any, so that it can be refreshed.
+	ClientID     string
+	ClientSecret string
+
+	// Source describes where the credentials were found, e.g. "env".
+	Source string
+
+	// save saves refreshed credentials where they were found, if set.
+	save func(creds *Credentials) error
+}
+
+// TokenSource returns a token source for the credentials, which refreshes the
+// token when it can. Refreshed tokens are saved where the credentials were
+// found, i.e. in the config file or the encrypted store; a token which cannot
+// be saved is an error, since the refresh token may have been rotated.
+func (c *Credentials) TokenSource(ctx context.Context) oauth2.TokenSource {
+	if c.ClientID == "" || c.Token.RefreshToken == "" {
+		return oauth2.StaticTokenSource(c.Token)
+	}
+
+	ts := NewConfig(c.Environment, c.ClientID, c.ClientSecret, "").TokenSource(ctx, c.Token)
+	if c.save == nil {
+		return ts
+	}
+	return &savingTokenSource{source: ts, creds: c, saved: c.Token}
+}
+
+// savingTokenSource saves the tokens of source which differ from the last saved one.
+type savingTokenSource struct {
+	source oauth2.TokenSource
+	creds  *Credentials
+
+	mu    sync.Mutex
+	saved *oauth2.Token
+}
+
+func (s *savingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.AccessToken == s.saved.AccessToken && token.RefreshToken == s.saved.RefreshToken {
+		return token, nil
+	}
+
+	creds := *s.creds
+	creds.Token = token
+	if err := creds.save(&creds); err != nil {
+		return nil, fmt.Errorf("cannot save refreshed token to %s: %w", creds.Source, err)
+	}
+	s.saved = token
+	return token, nil
+}
+
+// Client returns a client authenticated with the credentials, whose BaseURL
+// is the one of their environment, and configured with opts.
+func (c *Credentials) Client(ctx context.Context, opts ...osf.Option) (*osf.Client, error) {
+	if c.Environment == nil {
+		return nil, fmt.Errorf("credentials from %s have no environment", c.Source)
+	}
+	opts = append([]osf.Option{
+		osf.WithEnvironment(c.Environment),
+		osf.WithTokenSource(c.TokenSource(ctx)),
+	}, opts...)
+	return osf.NewClientWithOptions(opts...)
+}
+
+// CredentialsProvider finds credentials. It returns ErrNoCredentials if it has none.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (*Credentials, error)
+}
+
+// CredentialsProviderFunc is an adapter to allow the use of ordinary functions as a CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context) (*Credentials, error)
+
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// Chain is a CredentialsProvider returning the credentials of the first of its
+// providers which has some.
+type Chain []CredentialsProvider
+
+func (c Chain) Credentials(ctx context.Context) (*Credentials, error) {
+	for _, provider := range c {
+		creds, err := provider.Credentials(ctx)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return creds, err
+	}
+	return nil, ErrNoCredentials
+}
+
+// StaticProvider provides token for env, or for the environment named by
+// OSF_ENVIRONMENT if env is nil. It has no credentials if token is empty.
+func StaticProvider(token string, env *osf.Environment) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		if token == "" {
+			return nil, ErrNoCredentials
+		}
+		env, err := environmentOrDefault(env)
+		if err != nil {
+			return nil, err
+		}
+		return &Credentials{Environment: env, Token: &oauth2.Token{AccessToken: token}, Source: "static"}, nil
+	})
+}
+
+// EnvProvider provides the token of OSF_API_TOKEN for env, or for the
+// environment named by OSF_ENVIRONMENT if env is nil.
+func EnvProvider(env *osf.Environment) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		creds, err := StaticProvider(os.Getenv(EnvToken), env).Credentials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		creds.Source = "env"
+		return creds, nil
+	})
+}
+
+func environmentOrDefault(env *osf.Environment) (*osf.Environment, error) {
+	if env != nil {
+		return env, nil
+	}
+	return osf.EnvironmentByName(os.Getenv(EnvEnvironment))
+}
+
+// Profile is a named set of credentials of a config file.
+type Profile struct {
+	// Environment is the name of the environment of the profile, defaulting to production.
+	Environment string `json:"environment,omitempty"`
+	// BaseURL and AccountsURL override the ones of the environment, e.g. for a local OSF.
+	BaseURL     string `json:"base_url,omitempty"`
+	AccountsURL string `json:"accounts_url,omitempty"`
+
+	Token string `json:"token,omitempty"`
+	// RefreshToken, along with the application of ClientID and ClientSecret,
+	// lets the token be refreshed once it expires at Expiry.
+	RefreshToken string     `json:"refresh_token,omitempty"`
+	Expiry       *time.Time `json:"expiry,omitempty"`
+	ClientID     string     `json:"client_id,omitempty"`
+	ClientSecret string     `json:"client_secret,omitempty"`
+}
+
+func (p *Profile) environment() (*osf.Environment, error) {
+	env, err := osf.EnvironmentByName(p.Environment)
+	if err != nil && p.BaseURL == "" {
+		return nil, err
+	}
+	if p.BaseURL == "" && p.AccountsURL == "" {
+		return env, nil
+	}
+
+	custom := &osf.Environment{Name: p.Environment}
+	if env != nil {
+		*custom = *env
+	}
+	if p.BaseURL != "" {
+		custom.BaseURL = p.BaseURL
+	}
+	if p.AccountsURL != "" {
+		custom.AccountsURL = p.AccountsURL
+	}
+	return custom, nil
+}
+
+// ConfigFile is the config file holding named profiles, for instance:
+//
+//	{
+//		"profiles": {
+//			"default": {"token": "..."},
+//			"staging": {"environment": "staging", "token": "..."},
+//			"app": {"token": "...", "refresh_token": "...", "client_id": "..."}
+//		}
+//	}
+type ConfigFile struct {
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+// DefaultConfigPath returns the path of the config file, which is the value of
+// OSF_CONFIG_FILE if set, or osf/config.json in the user config directory.
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "osf", "config.json"), nil
+}
+
+// ConfigFileProvider provides the credentials of a profile of the config file
+// at path. path defaults to DefaultConfigPath(), and profile to the value of
+// OSF_PROFILE or DefaultProfile.
+func ConfigFileProvider(path string, profile string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		if path == "" {
+			var err error
+			if path, err = DefaultConfigPath(); err != nil {
+				return nil, err
+			}
+		}
+
+		cfg, err := readConfigFile(path)
+		if os.IsNotExist(err) {
+			return nil, ErrNoCredentials
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := profileOrDefault(profile)
+		p, ok := cfg.Profiles[name]
+		if !ok || p.Token == "" {
+			return nil, ErrNoCredentials
+		}
+
+		env, err := p.environment()
+		if err != nil {
+			return nil, fmt.Errorf("profile %q of %s: %w", name, path, err)
+		}
+		token := &oauth2.Token{AccessToken: p.Token, RefreshToken: p.RefreshToken}
+		if p.Expiry != nil {
+			token.Expiry = *p.Expiry
+		}
+		return &Credentials{
+			Environment:  env,
+			Token:        token,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			Source:       "config file " + path,
+			save: func(creds *Credentials) error {
+				return saveConfigToken(path, name, creds.Token)
+			},
+		}, nil
+	})
+}
+
+func readConfigFile(path string) (*ConfigFile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &ConfigFile{}
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// configFileMu serializes the updates of config files.
+var configFileMu sync.Mutex
+
+// saveConfigToken replaces the token of profile in the config file at path,
+// which is replaced atomically.
+func saveConfigToken(path string, profile string, token *oauth2.Token) error {
+	configFileMu.Lock()
+	defer configFileMu.Unlock()
+
+	cfg, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	p, ok := cfg.Profiles[profile]
+	if !ok {
+		return fmt.Errorf("profile %q of %s no longer exists", profile, path)
+	}
+	p.Token = token.AccessToken
+	p.RefreshToken = token.RefreshToken
+	p.Expiry = nil
+	if !token.Expiry.IsZero() {
+		p.Expiry = &token.Expiry
+	}
+
+	b, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func profileOrDefault(profile string) string {
+	if profile != "" {
+		return profile
+	}
+	if profile = os.Getenv(EnvProfile); profile != "" {
+		return profile
+	}
+	return DefaultProfile
+}
+
+// ChainOptions configures the default credential chain. The zero value is valid.
+type ChainOptions struct {
+	// Token is an explicit access token, which takes precedence over the others.
+	Token string
+	// Environment is the environment of Token and of OSF_API_TOKEN. Defaults
+	// to the one named by OSF_ENVIRONMENT, or production.
+	Environment *osf.Environment
+
+	// Profile is the profile of the config file and of the store. Defaults to
+	// the value of OSF_PROFILE, or DefaultProfile.
+	Profile string
+	// ConfigPath defaults to DefaultConfigPath().
+	ConfigPath string
+
+	// Store is the encrypted store. Defaults to the one at DefaultStorePath()
+	// with the passphrase of OSF_CREDENTIALS_PASSPHRASE, if set.
+	Store *EncryptedFileStore
+}
+
+// NewChain returns the default credential chain: the explicit token, then
+// OSF_API_TOKEN, then the config file, then the encrypted store.
+func NewChain(opts *ChainOptions) Chain {
+	if opts == nil {
+		opts = &ChainOptions{}
+	}
+
+	chain := Chain{
+		StaticProvider(opts.Token, opts.Environment),
+		EnvProvider(opts.Environment),
+		ConfigFileProvider(opts.ConfigPath, opts.Profile),
+	}
+
+	store := opts.Store
+	if store == nil {
+		if passphrase := os.Getenv(EnvPassphrase); passphrase != "" {
+			if path, err := DefaultStorePath(); err == nil {
+				store = NewEncryptedFileStore(path, passphrase)
+			}
+		}
+	}
+	if store != nil {
+		chain = append(chain, store.Provider(opts.Profile))
+	}
+
+	return chain
+}
+
+// NewClient returns a client authenticated with the credentials found by the
+// default credential chain, for their environment, and configured with clientOpts.
+func NewClient(ctx context.Context, opts *ChainOptions, clientOpts ...osf.Option) (*osf.Client, error) {
+	creds, err := NewChain(opts).Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return creds.Client(ctx, clientOpts...)
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+// setupCredentialsEnv isolates the tests from the credentials of the machine.
+func setupCredentialsEnv(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvEnvironment, "")
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvConfigFile, "")
+	t.Setenv(EnvPassphrase, "")
+	return dir
+}
+
+func writeConfigFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChain_Precedence(t *testing.T) {
+	dir := setupCredentialsEnv(t)
+	ctx := context.Background()
+
+	configPath := filepath.Join(dir, "config.json")
+	writeConfigFile(t, configPath, `{"profiles":{
+		"default":{"token":"from-config"},
+		"staging":{"environment":"staging","token":"from-staging-config"}
+	}}`)
+	t.Setenv(EnvConfigFile, configPath)
+
+	creds, err := NewChain(nil).Credentials(ctx)
+	if err != nil {
+		t.Fatalf("Chain.Credentials returned error: %v", err)
+	}
+	assert.Equal(t, "from-config", creds.Token.AccessToken)
+	assert.Equal(t, osf.EnvironmentProduction, creds.Environment)
+
+	creds, err = NewChain(&ChainOptions{Profile: "staging"}).Credentials(ctx)
+	if err != nil {
+		t.Fatalf("Chain.Credentials returned error: %v", err)
+	}
+	assert.Equal(t, "from-staging-config", creds.Token.AccessToken)
+	assert.Equal(t, osf.EnvironmentStaging, creds.Environment)
+
+	t.Setenv(EnvToken, "from-env")
+	t.Setenv(EnvEnvironment, "test")
+	creds, err = NewChain(nil).Credentials(ctx)
+	if err != nil {
+		t.Fatalf("Chain.Credentials returned error: %v", err)
+	}
+	assert.Equal(t, "from-env", creds.Token.AccessToken)
+	assert.Equal(t, osf.EnvironmentTest, creds.Environment)
+
+	creds, err = NewChain(&ChainOptions{Token: "explicit", Environment: osf.EnvironmentProduction}).Credentials(ctx)
+	if err != nil {
+		t.Fatalf("Chain.Credentials returned error: %v", err)
+	}
+	assert.Equal(t, "explicit", creds.Token.AccessToken)
+	assert.Equal(t, osf.EnvironmentProduction, creds.Environment)
+}
+
+func TestChain_NoCredentials(t *testing.T) {
+	setupCredentialsEnv(t)
+
+	_, err := NewChain(nil).Credentials(context.Background())
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	dir := setupCredentialsEnv(t)
+	path := filepath.Join(dir, "credentials.enc")
+
+	store := NewEncryptedFileStore(path, "correct horse")
+	err := store.Save("staging", &Credentials{
+		Environment: osf.EnvironmentStaging,
+		Token:       &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"},
+		ClientID:    "client",
+	})
+	if err != nil {
+		t.Fatalf("EncryptedFileStore.Save returned error: %v", err)
+	}
+
+	b, _ := os.ReadFile(path)
+	assert.NotContains(t, string(b), "refresh")
+
+	_, err = NewEncryptedFileStore(path, "wrong").Load("staging")
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	t.Setenv(EnvProfile, "staging")
+	client, err := NewClient(context.Background(), &ChainOptions{Store: store})
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	assert.Equal(t, osf.EnvironmentStaging.BaseURL, client.BaseURL.String())
+
+	creds, err := store.Load("staging")
+	if err != nil {
+		t.Fatalf("EncryptedFileStore.Load returned error: %v", err)
+	}
+	assert.Equal(t, "refresh", creds.Token.RefreshToken)
+	assert.Equal(t, "client", creds.ClientID)
+
+	assert.NoError(t, store.Delete("staging"))
+	_, err = store.Load("staging")
+	assert.ErrorIs(t, err, ErrNoCredentials)
+}
+
+func TestCredentials_TokenSource_SavesRefreshedToken(t *testing.T) {
+	dir := setupCredentialsEnv(t)
+	env, _, teardown := setupAccounts(t)
+	defer teardown()
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Hour)
+
+	store := NewEncryptedFileStore(filepath.Join(dir, "credentials.enc"), "correct horse")
+	err := store.Save("default", &Credentials{
+		Environment: env,
+		Token:       &oauth2.Token{AccessToken: "access1", RefreshToken: "refresh1", Expiry: expired},
+		ClientID:    "client",
+	})
+	if err != nil {
+		t.Fatalf("EncryptedFileStore.Save returned error: %v", err)
+	}
+
+	configPath := filepath.Join(dir, "config.json")
+	writeConfigFile(t, configPath, fmt.Sprintf(`{"profiles":{
+		"default":{"base_url":%q,"accounts_url":%q,"token":"access1","refresh_token":"refresh1","expiry":%q,"client_id":"client"},
+		"other":{"token":"untouched"}
+	}}`, env.BaseURL, env.AccountsURL, expired.Format(time.RFC3339)))
+
+	for _, provider := range []CredentialsProvider{store.Provider(""), ConfigFileProvider(configPath, "")} {
+		creds, err := provider.Credentials(ctx)
+		if err != nil {
+			t.Fatalf("Credentials returned error: %v", err)
+		}
+		token, err := creds.TokenSource(ctx).Token()
+		if err != nil {
+			t.Fatalf("TokenSource.Token returned error: %v", err)
+		}
+		assert.Equal(t, "access2", token.AccessToken)
+
+		// The refreshed token is found next time.
+		creds, err = provider.Credentials(ctx)
+		if err != nil {
+			t.Fatalf("Credentials returned error: %v", err)
+		}
+		assert.Equal(t, "access2", creds.Token.AccessToken, creds.Source)
+		assert.Equal(t, "refresh1", creds.Token.RefreshToken, creds.Source)
+		assert.True(t, creds.Token.Expiry.After(time.Now()), creds.Source)
+	}
+
+	creds, err := ConfigFileProvider(configPath, "other").Credentials(ctx)
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	assert.Equal(t, "untouched", creds.Token.AccessToken)
+}
+
+func TestCredentials_Client_NoEnvironment(t *testing.T) {
+	creds := &Credentials{Token: &oauth2.Token{AccessToken: "token", RefreshToken: "refresh"}, ClientID: "client", Source: "store"}
+	_, err := creds.Client(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+type loopbackResult struct {
+	token *oauth2.Token
+	err   error
+}
+
+// LoginLoopback runs an authorization code flow for command-line tools. It
+// serves the redirect URL of the config on the loopback interface, calls open
+// with the consent URL, typically to open it in a browser or print it, and
+// waits for the user to be redirected back.
+//
+// RedirectURL must be a loopback URL registered for the application, such as
+// http://127.0.0.1:8080/callback. If its port is 0, a free port is picked, in
+// which case the application must accept any port for the redirect URL.
+func (c *Config) LoginLoopback(ctx context.Context, open func(authURL string) error) (*oauth2.Token, error) {
+	redirect, err := url.Parse(c.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	if redirect.Scheme != "http" || !isLoopback(redirect.Hostname()) {
+		return nil, fmt.Errorf("redirect URL %q is not a loopback URL", c.RedirectURL)
+	}
+
+	ln, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer ln.Close()
+
+	// The redirect URL must match the registered one, so only a 0 port is
+	// replaced, by the actual one, and the host is kept, e.g. localhost.
+	if redirect.Port() == "0" {
+		_, port, err := net.SplitHostPort(ln.Addr().String())
+		if err != nil {
+			return nil, err
+		}
+		redirect.Host = net.JoinHostPort(redirect.Hostname(), port)
+	}
+	cfg := *c.Config
+	cfg.RedirectURL = redirect.String()
+	flow, err := (&Config{Config: &cfg, RevokeURL: c.RevokeURL}).NewFlow()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan loopbackResult, 1)
+	path := redirect.Path
+	if path == "" {
+		path = "/"
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// Responses which are not for this flow, e.g. forged ones, are rejected
+		// and the flow keeps waiting for its own.
+		if q.Get("state") != flow.State {
+			http.Error(w, "Invalid authorization response.", http.StatusBadRequest)
+			return
+		}
+
+		var res loopbackResult
+		if e := q.Get("error"); e != "" {
+			res.err = fmt.Errorf("authorization failed: %s", e)
+		} else {
+			res.token, res.err = flow.Exchange(ctx, q.Get("state"), q.Get("code"))
+		}
+		if res.err != nil {
+			fmt.Fprintln(w, "Authorization failed, you may close this window.")
+		} else {
+			fmt.Fprintln(w, "Authorization succeeded, you may close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	if err := open(flow.AuthCodeURL()); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-results:
+		return res.token, res.err
+	}
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/oauth2"
+)
+
+// scrypt parameters deriving the key of an EncryptedFileStore from its passphrase.
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32
+)
+
+// ErrWrongPassphrase is returned when an EncryptedFileStore cannot be decrypted.
+var ErrWrongPassphrase = errors.New("cannot decrypt credentials store: wrong passphrase or corrupted file")
+
+// EncryptedFileStore keeps credentials in a file encrypted with a passphrase,
+// with AES-GCM and a key derived with scrypt, for systems without a keyring.
+type EncryptedFileStore struct {
+	Path       string
+	Passphrase string
+
+	mu sync.Mutex
+}
+
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type storedCredentials struct {
+	Environment  *osf.Environment `json:"environment"`
+	Token        *oauth2.Token    `json:"token"`
+	ClientID     string           `json:"client_id,omitempty"`
+	ClientSecret string           `json:"client_secret,omitempty"`
+}
+
+// DefaultStorePath returns osf/credentials.enc in the user config directory.
+func DefaultStorePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "osf", "credentials.enc"), nil
+}
+
+func NewEncryptedFileStore(path string, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{Path: path, Passphrase: passphrase}
+}
+
+func (s *EncryptedFileStore) gcm(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(s.Passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// load returns the credentials of every profile, which are none if the file does not exist.
+func (s *EncryptedFileStore) load() (map[string]*storedCredentials, error) {
+	b, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return map[string]*storedCredentials{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f := &encryptedFile{}
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("invalid credentials store %s: %w", s.Path, err)
+	}
+	if f.Version != 1 {
+		return nil, fmt.Errorf("unsupported version %d of credentials store %s", f.Version, s.Path)
+	}
+
+	gcm, err := s.gcm(f.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	profiles := map[string]*storedCredentials{}
+	if err := json.Unmarshal(plaintext, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid credentials store %s: %w", s.Path, err)
+	}
+	return profiles, nil
+}
+
+// save encrypts profiles with a new salt and nonce, and replaces the file atomically.
+func (s *EncryptedFileStore) save(profiles map[string]*storedCredentials) error {
+	plaintext, err := json.Marshal(profiles)
+	if err != nil {
+		return err
+	}
+
+	f := &encryptedFile{Version: 1, Salt: make([]byte, 16)}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return err
+	}
+	gcm, err := s.gcm(f.Salt)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	f.Ciphertext = gcm.Seal(nil, f.Nonce, plaintext, nil)
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.Path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Load returns the credentials of profile, or ErrNoCredentials if there are none.
+func (s *EncryptedFileStore) Load(profile string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	stored, ok := profiles[profile]
+	if !ok || stored.Token == nil {
+		return nil, ErrNoCredentials
+	}
+	return &Credentials{
+		Environment:  stored.Environment,
+		Token:        stored.Token,
+		ClientID:     stored.ClientID,
+		ClientSecret: stored.ClientSecret,
+		Source:       "store " + s.Path,
+		save: func(creds *Credentials) error {
+			return s.Save(profile, creds)
+		},
+	}, nil
+}
+
+// Save saves creds under profile, replacing the previous ones.
+func (s *EncryptedFileStore) Save(profile string, creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := s.load()
+	if err != nil {
+		return err
+	}
+	profiles[profile] = &storedCredentials{
+		Environment:  creds.Environment,
+		Token:        creds.Token,
+		ClientID:     creds.ClientID,
+		ClientSecret: creds.ClientSecret,
+	}
+	return s.save(profiles)
+}
+
+// Delete deletes the credentials of profile, if any.
+func (s *EncryptedFileStore) Delete(profile string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profiles, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles[profile]; !ok {
+		return nil
+	}
+	delete(profiles, profile)
+	return s.save(profiles)
+}
+
+// Provider returns a CredentialsProvider of the credentials of profile, which
+// defaults to the value of OSF_PROFILE or DefaultProfile.
+func (s *EncryptedFileStore) Provider(profile string) CredentialsProvider {
+	return CredentialsProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		return s.Load(profileOrDefault(profile))
+	})
+}
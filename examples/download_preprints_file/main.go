@@ -17,6 +17,7 @@ func main() {
 	tc := oauth2.NewClient(ctx, ts)
 
 	client := osf.NewClient(tc)
+	client.ProgressReporter = osf.NewProgressBar(os.Stderr)
 
 	file, _, err := client.Preprints.GetPreprintPrimaryFileByID(ctx, "xfdsr")
 	if err != nil {
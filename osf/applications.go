@@ -0,0 +1,133 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type ApplicationsService service
+
+type ApplicationLinks struct {
+	Self *string `json:"self"`
+	Html *string `json:"html"`
+}
+
+// Application is an OAuth2 developer application of the authenticated user.
+// Its ID is its client ID.
+type Application struct {
+	ID string `json:"id"`
+
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	HomeURL      string `json:"home_url"`
+	CallbackURL  string `json:"callback_url"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Owner        string `json:"owner"`
+	DateCreated  *Time  `json:"date_created"`
+
+	Links *ApplicationLinks `json:"-"`
+}
+
+type ApplicationRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	HomeURL     *string `json:"home_url,omitempty"`
+	CallbackURL *string `json:"callback_url,omitempty"`
+}
+
+type ApplicationsListOptions struct {
+	ListOptions
+}
+
+func transformApplication(raw *Data[*Application, *ApplicationLinks]) (*Application, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func (s *ApplicationsService) ListApplications(ctx context.Context, opts *ApplicationsListOptions) ([]*Application, *ManyPayload[*Application, *ApplicationLinks], error) {
+	return listResources(s.client, ctx, "applications/", opts, transformApplication)
+}
+
+func (s *ApplicationsService) GetApplicationByID(ctx context.Context, clientID string) (*Application, *SinglePayload[*Application, *ApplicationLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("applications/%s/", clientID), transformApplication)
+}
+
+func (s *ApplicationsService) CreateApplication(ctx context.Context, input *ApplicationRequest) (*Application, *SinglePayload[*Application, *ApplicationLinks], error) {
+	body := &SinglePayload[*ApplicationRequest, interface{}]{
+		Data: &Data[*ApplicationRequest, interface{}]{
+			Type:       TypeApplications,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "applications/", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformApplication)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *ApplicationsService) UpdateApplication(ctx context.Context, clientID string, input *ApplicationRequest) (*Application, *SinglePayload[*Application, *ApplicationLinks], error) {
+	body := &SinglePayload[*ApplicationRequest, interface{}]{
+		Data: &Data[*ApplicationRequest, interface{}]{
+			Type:       TypeApplications,
+			ID:         &clientID,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("applications/%s/", clientID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformApplication)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// ResetApplicationSecret replaces the client secret of an application, which
+// revokes all the tokens issued to it.
+func (s *ApplicationsService) ResetApplicationSecret(ctx context.Context, clientID string) (*Application, *SinglePayload[*Application, *ApplicationLinks], error) {
+	body := &SinglePayload[*ApplicationRequest, interface{}]{
+		Data: &Data[*ApplicationRequest, interface{}]{
+			Type:       TypeApplications,
+			ID:         &clientID,
+			Attributes: &ApplicationRequest{},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("applications/%s/reset/", clientID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformApplication)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// DeleteApplication deactivates an application, which revokes all the tokens issued to it.
+func (s *ApplicationsService) DeleteApplication(ctx context.Context, clientID string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("applications/%s/", clientID), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
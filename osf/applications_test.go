@@ -0,0 +1,27 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplicationsService_ResetApplicationSecret(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/applications/cid123/reset/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"data":{"id":"cid123","type":"applications","attributes":{"name":"Harvester","client_id":"cid123","client_secret":"n3w","callback_url":"http://localhost:8080/callback"}}}`)
+	})
+
+	app, _, err := client.Applications.ResetApplicationSecret(context.Background(), "cid123")
+	if err != nil {
+		t.Fatalf("Applications.ResetApplicationSecret returned error: %v", err)
+	}
+	assert.Equal(t, "cid123", app.ID)
+	assert.Equal(t, "n3w", app.ClientSecret)
+}
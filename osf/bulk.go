@@ -0,0 +1,271 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ErrBulkAborted is the error of the items of a failed bulk request which have
+// no error of their own: OSF applies bulk requests entirely or not at all.
+var ErrBulkAborted = errors.New("bulk request aborted by errors of other items")
+
+// BulkResult is the outcome of fetching, creating, updating or deleting one of
+// the resources of a bulk operation.
+type BulkResult[T any] struct {
+	ID    string
+	Value T
+	Err   error
+}
+
+type BulkOptions struct {
+	// Concurrency is the maximum number of concurrent requests. Defaults to 4.
+	Concurrency int
+	// BatchSize is the maximum number of IDs listed by a single filter[id]
+	// request. Defaults to, and is at most, 100.
+	BatchSize int
+	// DisableBatching fetches every resource on its own, e.g. for endpoints
+	// whose lists hide some resources, such as unpublished preprints.
+	DisableBatching bool
+}
+
+func (o *BulkOptions) concurrency() int {
+	if o == nil || o.Concurrency <= 0 {
+		return 4
+	}
+	return o.Concurrency
+}
+
+func (o *BulkOptions) batchSize() int {
+	if o == nil || o.BatchSize <= 0 || o.BatchSize > 100 {
+		return 100
+	}
+	return o.BatchSize
+}
+
+// baseGUID strips the version suffix of a GUID, e.g. "xfdsr_v2" becomes "xfdsr".
+func baseGUID(id string) string {
+	if i := strings.Index(id, "_v"); i > 0 {
+		return id[:i]
+	}
+	return id
+}
+
+// getResourcesByID fetches the resources of the list endpoint u with ids,
+// listing them in batches with filter[id] where possible, then getting the
+// ones missing from the lists one by one with get. The IDs of a batch whose
+// list failed fail with its error. At most opts.Concurrency requests are
+// performed at once. The results are in the order of ids.
+func getResourcesByID[T any, U any](c *Client, ctx context.Context, u string, ids []string, opts *BulkOptions, idOf func(T) string, get func(ctx context.Context, id string) (T, error), build ...TransformDataFn[T, U]) ([]*BulkResult[T], error) {
+	// Fetch every ID once, even if repeated.
+	var unique []string
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	var mu sync.Mutex
+	found := map[string]T{}
+	errs := map[string]error{}
+
+	sem := make(chan struct{}, opts.concurrency())
+	run := func(wg *sync.WaitGroup, fn func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			fn()
+		}()
+	}
+
+	if opts == nil || !opts.DisableBatching {
+		var wg sync.WaitGroup
+		size := opts.batchSize()
+		for start := 0; start < len(unique); start += size {
+			end := start + size
+			if end > len(unique) {
+				end = len(unique)
+			}
+			batch := unique[start:end]
+
+			run(&wg, func() {
+				objs, err := listAllResources(c, ctx, u, map[string]string{"id": strings.Join(batch, ",")}, build...)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					// Fetching them one by one would only add to the load, e.g. when OSF throttles requests.
+					for _, id := range batch {
+						errs[id] = err
+					}
+					return
+				}
+				for _, obj := range objs {
+					id := idOf(obj)
+					found[id] = obj
+					// Requested without version, returned with one, or the other way around.
+					if _, ok := found[baseGUID(id)]; !ok {
+						found[baseGUID(id)] = obj
+					}
+				}
+			})
+		}
+		wg.Wait()
+	}
+
+	// Only the IDs missing from successful lists are fetched one by one.
+	var missing []string
+	for _, id := range unique {
+		_, ok := found[id]
+		if _, failed := errs[id]; !ok && !failed {
+			missing = append(missing, id)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range missing {
+		id := id
+		run(&wg, func() {
+			obj, err := get(ctx, id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			found[id] = obj
+		})
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*BulkResult[T], 0, len(ids))
+	for _, id := range ids {
+		result := &BulkResult[T]{ID: id}
+		if obj, ok := found[id]; ok {
+			result.Value = obj
+		} else if err, ok := errs[id]; ok {
+			result.Err = err
+		} else {
+			result.Err = fmt.Errorf("%s not found", id)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// bulkItemIndex returns the index of the item of a bulk request an error
+// points to, e.g. 1 for "/data/1/attributes/title", or -1 if there is none.
+func bulkItemIndex(e *Error) int {
+	if e.Source == nil || !strings.HasPrefix(e.Source.Pointer, "/data/") {
+		return -1
+	}
+	index := strings.SplitN(strings.TrimPrefix(e.Source.Pointer, "/data/"), "/", 2)[0]
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
+// bulkFailure returns the results of the items ids of a failed bulk request.
+// The JSON:API errors of the response are mapped to the items they point to;
+// the other items fail with ErrBulkAborted.
+func bulkFailure[T any](ids []string, err error) ([]*BulkResult[T], error) {
+	results := make([]*BulkResult[T], 0, len(ids))
+	for _, id := range ids {
+		results = append(results, &BulkResult[T]{ID: id, Err: err})
+	}
+
+	errs, ok := err.(Errors)
+	if !ok {
+		return results, err
+	}
+
+	itemErrs := make([]Errors, len(ids))
+	for _, e := range errs {
+		if i := bulkItemIndex(e); i >= 0 && i < len(ids) {
+			itemErrs[i] = append(itemErrs[i], e)
+		}
+	}
+	for i, result := range results {
+		if len(itemErrs[i]) > 0 {
+			result.Err = itemErrs[i]
+		} else {
+			result.Err = ErrBulkAborted
+		}
+	}
+	return results, err
+}
+
+// doBulk performs a bulk request on the resources ids, which are empty for
+// creations, and returns the results in the order of ids. The resources of
+// the response are matched to ids by ID, or by position for creations. On
+// failure, the error of the request is returned along with the results.
+func doBulk[T any, U any](c *Client, ctx context.Context, req *http.Request, ids []string, build ...TransformDataFn[T, U]) ([]*BulkResult[T], error) {
+	res, err := doMany(c, ctx, req, build...)
+	if err != nil {
+		return bulkFailure[T](ids, err)
+	}
+
+	objs := res.TransformedData()
+	byID := make(map[string]T, len(objs))
+	for i, raw := range res.Data {
+		if raw.ID != nil {
+			byID[*raw.ID] = objs[i]
+		}
+	}
+
+	results := make([]*BulkResult[T], 0, len(ids))
+	for i, id := range ids {
+		result := &BulkResult[T]{ID: id}
+		if id != "" {
+			obj, ok := byID[id]
+			if ok {
+				result.Value = obj
+			} else {
+				result.Err = fmt.Errorf("%s missing from the response", id)
+			}
+		} else if i < len(objs) {
+			result.Value = objs[i]
+			if res.Data[i].ID != nil {
+				result.ID = *res.Data[i].ID
+			}
+		} else {
+			result.Err = fmt.Errorf("item %d missing from the response", i)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// doBulkNoContent performs a bulk request, such as a deletion, whose response
+// has no content. The values of the results are zero.
+func doBulkNoContent[T any](c *Client, ctx context.Context, req *http.Request, ids []string) ([]*BulkResult[T], error) {
+	if err := doNoContent(c, ctx, req); err != nil {
+		return bulkFailure[T](ids, err)
+	}
+
+	results := make([]*BulkResult[T], 0, len(ids))
+	for _, id := range ids {
+		results = append(results, &BulkResult[T]{ID: id})
+	}
+	return results, nil
+}
@@ -0,0 +1,259 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreprintsService_GetPreprintsByID(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	var mu sync.Mutex
+	var filters []string
+	mux.HandleFunc("/preprints/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/preprints/"), "/")
+		switch id {
+		case "":
+			mu.Lock()
+			filters = append(filters, r.URL.Query().Get("filter[id]"))
+			mu.Unlock()
+			// Unpublished preprints are not listed.
+			var data []string
+			for _, id := range strings.Split(r.URL.Query().Get("filter[id]"), ",") {
+				switch id {
+				case "aaaaa", "bbbbb":
+					data = append(data, fmt.Sprintf(`{"id":"%s","type":"preprints","attributes":{"title":"%s"}}`, id, strings.ToUpper(id)))
+				case "ccccc":
+					data = append(data, `{"id":"ccccc_v2","type":"preprints","attributes":{"title":"CCCCC"}}`)
+				}
+			}
+			fmt.Fprintf(w, `{"data":[%s],"links":{"meta":{"total":%d,"per_page":100}}}`, strings.Join(data, ","), len(data))
+		case "ddddd":
+			fmt.Fprint(w, `{"data":{"id":"ddddd","type":"preprints","attributes":{"title":"DDDDD"}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errors":[{"detail":"Not found."}]}`)
+		}
+	})
+
+	ids := []string{"ddddd", "aaaaa", "eeeee", "ccccc", "bbbbb", "aaaaa"}
+	results, err := client.Preprints.GetPreprintsByID(context.Background(), ids, &BulkOptions{BatchSize: 3, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Preprints.GetPreprintsByID returned error: %v", err)
+	}
+
+	if assert.Len(t, results, len(ids)) {
+		for i, result := range results {
+			assert.Equal(t, ids[i], result.ID)
+		}
+		assert.Equal(t, "DDDDD", results[0].Value.Title)
+		assert.Equal(t, "AAAAA", results[1].Value.Title)
+		assert.EqualError(t, results[2].Err, "Not found.")
+		assert.Equal(t, "ccccc_v2", results[3].Value.ID)
+		assert.Equal(t, "BBBBB", results[4].Value.Title)
+		assert.Same(t, results[1].Value, results[5].Value)
+	}
+	assert.ElementsMatch(t, []string{"ddddd,aaaaa,eeeee", "ccccc,bbbbb"}, filters)
+}
+
+func TestPreprintsService_GetPreprintsByID_DisableBatching(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/preprints/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/preprints/"), "/")
+		if id == "" {
+			t.Error("unexpected list request")
+		}
+		fmt.Fprintf(w, `{"data":{"id":"%s","type":"preprints","attributes":{}}}`, id)
+	})
+
+	results, err := client.Preprints.GetPreprintsByID(context.Background(), []string{"aaaaa", "bbbbb"}, &BulkOptions{DisableBatching: true})
+	if err != nil {
+		t.Fatalf("Preprints.GetPreprintsByID returned error: %v", err)
+	}
+	assert.Equal(t, "aaaaa", results[0].Value.ID)
+	assert.Equal(t, "bbbbb", results[1].Value.ID)
+}
+
+func TestPreprintsService_GetPreprintsByID_BatchError(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/preprints/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/preprints/"), "/")
+		if id != "" {
+			t.Errorf("unexpected request of %s", id)
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"errors":[{"detail":"Request was throttled."}]}`)
+	})
+
+	results, err := client.Preprints.GetPreprintsByID(context.Background(), []string{"aaaaa", "bbbbb"}, nil)
+	if err != nil {
+		t.Fatalf("Preprints.GetPreprintsByID returned error: %v", err)
+	}
+	if assert.Len(t, results, 2) {
+		assert.EqualError(t, results[0].Err, "Request was throttled.")
+		assert.EqualError(t, results[1].Err, "Request was throttled.")
+	}
+}
+
+func TestNodesService_BulkUpdateNodes(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		assert.Equal(t, "application/vnd.api+json; ext=bulk", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"data":[
+			{"type":"nodes","id":"aaaaa","attributes":{"tags":["x","y"]}},
+			{"type":"nodes","id":"bbbbb","attributes":{"tags":[]}}
+		]}`, string(body))
+		// The response is not in the order of the request.
+		fmt.Fprint(w, `{"data":[
+			{"id":"bbbbb","type":"nodes","attributes":{"tags":[]}},
+			{"id":"aaaaa","type":"nodes","attributes":{"tags":["x","y"]}}
+		]}`)
+	})
+
+	results, err := client.Nodes.BulkUpdateNodes(context.Background(), []*NodeUpdate{
+		{NodeID: "aaaaa", Input: &NodeRequest{Tags: &[]string{"x", "y"}}},
+		{NodeID: "bbbbb", Input: &NodeRequest{Tags: &[]string{}}},
+	})
+	if err != nil {
+		t.Fatalf("Nodes.BulkUpdateNodes returned error: %v", err)
+	}
+
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "aaaaa", results[0].ID)
+		assert.Equal(t, []string{"x", "y"}, results[0].Value.Tags)
+		assert.Equal(t, "bbbbb", results[1].ID)
+		assert.Equal(t, "bbbbb", results[1].Value.ID)
+	}
+}
+
+func TestNodesService_BulkCreateNodes(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":[
+			{"id":"aaaaa","type":"nodes","attributes":{"title":"A"}},
+			{"id":"bbbbb","type":"nodes","attributes":{"title":"B"}}
+		]}`)
+	})
+
+	results, err := client.Nodes.BulkCreateNodes(context.Background(), []*NodeRequest{
+		{Title: StringPointer("A"), Category: StringPointer(NodeCategoryProject)},
+		{Title: StringPointer("B"), Category: StringPointer(NodeCategoryData)},
+	})
+	if err != nil {
+		t.Fatalf("Nodes.BulkCreateNodes returned error: %v", err)
+	}
+
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "aaaaa", results[0].ID)
+		assert.Equal(t, "B", results[1].Value.Title)
+	}
+}
+
+func TestNodesService_BulkUpdateNodeContributors(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/n1/contributors/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"data":[
+			{"id":"n1-u2","type":"contributors","attributes":{"permission":"read"}},
+			{"id":"n1-u1","type":"contributors","attributes":{"permission":"admin"}}
+		]}`)
+	})
+
+	results, err := client.Nodes.BulkUpdateNodeContributors(context.Background(), "n1", []*ContributorUpdate{
+		{UserID: "u1", Input: &ContributorRequest{Permission: StringPointer(PermissionAdmin)}},
+		{UserID: "u2", Input: &ContributorRequest{Permission: StringPointer(PermissionRead)}},
+		{UserID: "u3", Input: &ContributorRequest{Permission: StringPointer(PermissionRead)}},
+	})
+	if err != nil {
+		t.Fatalf("Nodes.BulkUpdateNodeContributors returned error: %v", err)
+	}
+
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "u1", results[0].ID)
+		assert.Equal(t, PermissionAdmin, results[0].Value.Permission)
+		assert.Equal(t, "u2", results[1].ID)
+		assert.Equal(t, PermissionRead, results[1].Value.Permission)
+		assert.Equal(t, "u3", results[2].ID)
+		assert.Error(t, results[2].Err)
+	}
+}
+
+func TestNodesService_BulkUpdateNodeContributors_errors(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/n1/contributors/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"data":[
+			{"type":"contributors","id":"n1-u1","attributes":{"permission":"admin"}},
+			{"type":"contributors","id":"n1-u2","attributes":{"permission":"owner"}},
+			{"type":"contributors","id":"n1-u3","attributes":{"bibliographic":false}}
+		]}`, string(body))
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[
+			{"source":{"pointer":"/data/1/attributes/permission"},"detail":"\"owner\" is not a valid choice."},
+			{"source":{"pointer":"/data/2"},"detail":"Must have at least one bibliographic contributor."}
+		]}`)
+	})
+
+	results, err := client.Nodes.BulkUpdateNodeContributors(context.Background(), "n1", []*ContributorUpdate{
+		{UserID: "u1", Input: &ContributorRequest{Permission: StringPointer(PermissionAdmin)}},
+		{UserID: "u2", Input: &ContributorRequest{Permission: StringPointer("owner")}},
+		{UserID: "u3", Input: &ContributorRequest{Bibliographic: BoolPointer(false)}},
+	})
+	assert.IsType(t, Errors{}, err)
+
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, "u1", results[0].ID)
+		assert.ErrorIs(t, results[0].Err, ErrBulkAborted)
+		assert.EqualError(t, results[1].Err, `"owner" is not a valid choice. (/data/1/attributes/permission)`)
+		assert.EqualError(t, results[2].Err, "Must have at least one bibliographic contributor. (/data/2)")
+	}
+}
+
+func TestNodesService_BulkDeleteNodeContributors(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/n1/contributors/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		assert.Equal(t, "application/vnd.api+json; ext=bulk", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"data":[{"type":"contributors","id":"n1-u1"},{"type":"contributors","id":"n1-u2"}]}`, string(body))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	results, err := client.Nodes.BulkDeleteNodeContributors(context.Background(), "n1", "u1", "u2")
+	if err != nil {
+		t.Fatalf("Nodes.BulkDeleteNodeContributors returned error: %v", err)
+	}
+
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, "u2", results[1].ID)
+		assert.NoError(t, results[1].Err)
+	}
+}
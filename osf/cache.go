@@ -0,0 +1,404 @@
+package osf
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStatus tells whether a payload was served from Client.Cache.
+type CacheStatus string
+
+const (
+	// CacheMiss is the status of a payload fetched from OSF, and stored in the cache if possible.
+	CacheMiss CacheStatus = "miss"
+	// CacheHit is the status of a payload served from the cache without contacting OSF.
+	CacheHit CacheStatus = "hit"
+	// CacheRevalidated is the status of a cached payload which OSF confirmed is unchanged.
+	CacheRevalidated CacheStatus = "revalidated"
+)
+
+// FromCache reports whether the payload was served from the cache.
+func (s CacheStatus) FromCache() bool {
+	return s == CacheHit || s == CacheRevalidated
+}
+
+type cacheStatusSetter interface {
+	setCacheStatus(status CacheStatus)
+}
+
+// Cache stores the responses of a client, see Client.Cache. Clients only
+// share the entries of a cache if they have the same Client.CacheScope. The
+// responses of applications and tokens, which hold secrets, are never cached.
+type Cache interface {
+	// Get returns the value stored under key, or nil if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// WithCache caches the responses of GET requests in cache. Responses with an
+// ETag or Last-Modified header are revalidated on every request, and the
+// others are served from the cache for ttl.
+//
+// The cached responses of a resource type, lists included, are invalidated
+// when the client modifies a resource of that type, e.g. a PATCH of
+// preprints/{id}/ invalidates every cached preprints/ response. Changes made
+// by other clients, including other processes sharing a DiskCache, or to
+// related resources of other types, are only seen once the responses are
+// revalidated or expire.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(o *clientOptions) error {
+		o.cache = cache
+		o.cacheTTL = ttl
+		return nil
+	}
+}
+
+// WithCacheScope sets Client.CacheScope. It defaults to a digest of the token
+// of WithToken, or to a random scope with WithTokenSource, in which case
+// cached responses are not reused by other clients, e.g. after a restart.
+// Setting it, e.g. to the ID of the authenticated user, reuses them.
+func WithCacheScope(scope string) Option {
+	return func(o *clientOptions) error {
+		o.cacheScope = scope
+		return nil
+	}
+}
+
+// tokenCacheScope returns the cache scope of a client authenticated with
+// token, or with a token source if token is empty.
+func tokenCacheScope(token string) (string, error) {
+	if token != "" {
+		sum := sha256.Sum256([]byte(token))
+		return hex.EncodeToString(sum[:8]), nil
+	}
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type cacheEntry struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	StoredAt   time.Time   `json:"stored_at"`
+}
+
+func (e *cacheEntry) hasValidators() bool {
+	return e.Header.Get("ETag") != "" || e.Header.Get("Last-Modified") != ""
+}
+
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheKey identifies the resource of req, for the credentials of the client
+// and of the Authorization header of req if any.
+func (c *Client) cacheKey(req *http.Request) string {
+	key := c.cacheScope() + " " + req.URL.String()
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
+
+// cacheScope returns Client.CacheScope or, if it is empty, a random scope
+// drawn on first use, since the cache cannot tell apart the credentials of the
+// underlying HTTP client. It is empty if no scope could be drawn.
+func (c *Client) cacheScope() string {
+	if c.CacheScope != "" {
+		return c.CacheScope
+	}
+
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if c.randomCacheScope == "" {
+		c.randomCacheScope, _ = tokenCacheScope("")
+	}
+	return c.randomCacheScope
+}
+
+// resourceType returns the resource type of the path of req, e.g. "preprints".
+func (c *Client) resourceType(req *http.Request) string {
+	path := strings.Trim(strings.TrimPrefix(req.URL.Path, c.BaseURL.Path), "/")
+	return strings.SplitN(path, "/", 2)[0]
+}
+
+// sensitiveResourceTypes are never cached, since their responses hold
+// secrets, such as the client secrets of applications, which DiskCache would
+// write in plain text.
+var sensitiveResourceTypes = map[string]bool{
+	TypeApplications: true,
+	TypeTokens:       true,
+}
+
+// setModified records that the client modified the resource type of req.
+func (c *Client) setModified(req *http.Request) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if c.cacheModified == nil {
+		c.cacheModified = map[string]time.Time{}
+	}
+	c.cacheModified[req.URL.Host+" "+c.resourceType(req)] = time.Now()
+}
+
+// modifiedSince reports whether the client modified the resource type of req after t.
+func (c *Client) modifiedSince(req *http.Request, t time.Time) bool {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	return c.cacheModified[req.URL.Host+" "+c.resourceType(req)].After(t)
+}
+
+// isCacheable reports whether resp is worth storing: a JSON:API payload, not
+// file contents, which OSF does not forbid to store.
+func isCacheable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusOK &&
+		strings.Contains(resp.Header.Get("Content-Type"), "json") &&
+		!strings.Contains(resp.Header.Get("Cache-Control"), "no-store")
+}
+
+func (c *Client) loadCacheEntry(ctx context.Context, key string) *cacheEntry {
+	b, err := c.Cache.Get(ctx, key)
+	if err != nil || b == nil {
+		return nil
+	}
+	entry := &cacheEntry{}
+	if err := json.Unmarshal(b, entry); err != nil {
+		return nil
+	}
+	return entry
+}
+
+func (c *Client) storeCacheEntry(ctx context.Context, key string, entry *cacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := c.Cache.Set(ctx, key, b); err != nil {
+		c.logger().Warn("cannot store OSF response in cache", "error", err)
+	}
+}
+
+// cachingHandler serves GET requests from Client.Cache when possible, and
+// invalidates the cached resources other requests modify.
+func (c *Client) cachingHandler(next RequestHandler) RequestHandler {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		if c.Cache == nil {
+			return next(ctx, req)
+		}
+
+		key := c.cacheKey(req)
+		if req.Method != http.MethodGet {
+			resp, err := next(ctx, req)
+			if err == nil && req.Method != http.MethodHead && req.Method != http.MethodOptions {
+				c.Cache.Delete(ctx, key)
+				c.setModified(req)
+			}
+			return resp, err
+		}
+		if sensitiveResourceTypes[c.resourceType(req)] || c.cacheScope() == "" {
+			return next(ctx, req)
+		}
+
+		_, info := withRequestInfo(ctx)
+		entry := c.loadCacheEntry(ctx, key)
+		if entry != nil {
+			if !entry.hasValidators() {
+				// Entries with validators are revalidated anyway.
+				if c.modifiedSince(req, entry.StoredAt) {
+					entry = nil
+				} else if time.Since(entry.StoredAt) < c.CacheTTL {
+					info.cache = CacheHit
+					return entry.response(req), nil
+				}
+			} else {
+				if etag := entry.Header.Get("ETag"); etag != "" {
+					req.Header.Set("If-None-Match", etag)
+				}
+				if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+					req.Header.Set("If-Modified-Since", lastModified)
+				}
+			}
+		}
+
+		resp, err := next(ctx, req)
+		info.cache = CacheMiss
+		if err != nil {
+			return resp, err
+		}
+
+		if entry != nil && resp.StatusCode == http.StatusNotModified {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			entry.StoredAt = time.Now()
+			c.storeCacheEntry(ctx, key, entry)
+			info.cache = CacheRevalidated
+			return entry.response(req), nil
+		}
+
+		if isCacheable(resp) {
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+
+			c.storeCacheEntry(ctx, key, &cacheEntry{
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header.Clone(),
+				Body:       body,
+				StoredAt:   time.Now(),
+			})
+		}
+		return resp, nil
+	}
+}
+
+// MemoryCache is a Cache keeping the most recently used entries in memory.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    *list.List
+	index      map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemoryCache returns a MemoryCache evicting the least recently used
+// entries beyond maxEntries, or never if maxEntries is 0.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{maxEntries: maxEntries, entries: list.New(), index: map[string]*list.Element{}}
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.index[key]
+	if !ok {
+		return nil, nil
+	}
+	m.entries.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).value, nil
+}
+
+func (m *MemoryCache) Set(ctx context.Context, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	value = append([]byte(nil), value...)
+	if e, ok := m.index[key]; ok {
+		e.Value.(*memoryCacheEntry).value = value
+		m.entries.MoveToFront(e)
+		return nil
+	}
+
+	m.index[key] = m.entries.PushFront(&memoryCacheEntry{key: key, value: value})
+	if m.maxEntries > 0 && m.entries.Len() > m.maxEntries {
+		oldest := m.entries.Back()
+		m.entries.Remove(oldest)
+		delete(m.index, oldest.Value.(*memoryCacheEntry).key)
+	}
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.index[key]; ok {
+		m.entries.Remove(e)
+		delete(m.index, key)
+	}
+	return nil
+}
+
+// Len returns the number of entries in the cache.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.entries.Len()
+}
+
+// DiskCache is a Cache keeping each entry in a file of a directory, so that
+// it survives restarts. Files are replaced atomically.
+type DiskCache struct {
+	Dir string
+}
+
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{Dir: dir}
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.Dir, hex.EncodeToString(sum[:])+".cache")
+}
+
+func (d *DiskCache) Get(ctx context.Context, key string) ([]byte, error) {
+	b, err := os.ReadFile(d.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (d *DiskCache) Set(ctx context.Context, key string, value []byte) error {
+	if err := os.MkdirAll(d.Dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(d.Dir, ".cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(value); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(key))
+}
+
+func (d *DiskCache) Delete(ctx context.Context, key string) error {
+	err := os.Remove(d.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,297 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CacheRevalidation(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+
+	requests := 0
+	mux.HandleFunc("/preprints/xfdsr/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"preprints","attributes":{"title":"Cached"}}}`)
+	})
+
+	ctx := context.Background()
+	preprint, res, err := client.Preprints.GetPreprintByID(ctx, "xfdsr")
+	if err != nil {
+		t.Fatalf("Preprints.GetPreprintByID returned error: %v", err)
+	}
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+	assert.Equal(t, "Cached", preprint.Title)
+
+	preprint, res, err = client.Preprints.GetPreprintByID(ctx, "xfdsr")
+	if err != nil {
+		t.Fatalf("Preprints.GetPreprintByID returned error: %v", err)
+	}
+	assert.Equal(t, CacheRevalidated, res.CacheStatus)
+	assert.True(t, res.CacheStatus.FromCache())
+	assert.Equal(t, "xfdsr", preprint.ID)
+	assert.Equal(t, "Cached", preprint.Title)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClient_CacheTTL(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+	client.CacheTTL = time.Minute
+
+	requests := 0
+	mux.HandleFunc("/preprint_providers/", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"psyarxiv","type":"preprint_providers","attributes":{"name":"PsyArXiv"}}],"links":{"meta":{"total":1,"per_page":10}}}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		providers, res, err := client.PreprintProviders.ListPreprintProviders(ctx, nil)
+		if err != nil {
+			t.Fatalf("PreprintProviders.ListPreprintProviders returned error: %v", err)
+		}
+		assert.Len(t, providers, 1)
+		if i == 0 {
+			assert.Equal(t, CacheMiss, res.CacheStatus)
+		} else {
+			assert.Equal(t, CacheHit, res.CacheStatus)
+		}
+	}
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, 1, client.Cache.(*MemoryCache).Len())
+}
+
+func TestClient_CacheInvalidation(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+	client.CacheTTL = time.Minute
+
+	mux.HandleFunc("/comments/c1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"c1","type":"comments","attributes":{"content":"Nice"}}}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.Cache.(*MemoryCache).Len())
+
+	assert.NoError(t, client.Comments.DeleteComment(ctx, "c1"))
+	_, res, err := client.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+}
+
+func TestClient_CacheInvalidation_lists(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+	client.CacheTTL = time.Minute
+
+	requests := 0
+	mux.HandleFunc("/preprints", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":[{"id":"xfdsr","type":"preprints","attributes":{}}],"links":{"meta":{"total":1,"per_page":10}}}`)
+	})
+	mux.HandleFunc("/preprints/xfdsr/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"preprints","attributes":{"title":"New"}}}`)
+	})
+
+	ctx := context.Background()
+	opts := &PreprintsListOptions{ListOptions: ListOptions{Page: 1}}
+	_, res, err := client.Preprints.ListPreprints(ctx, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+	_, res, err = client.Preprints.ListPreprints(ctx, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, res.CacheStatus)
+
+	// Updating a preprint invalidates the cached lists of preprints.
+	_, _, err = client.Preprints.UpdatePreprint(ctx, "xfdsr", &PreprintRequest{Title: StringPointer("New")}, nil)
+	assert.NoError(t, err)
+	_, res, err = client.Preprints.ListPreprints(ctx, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+	assert.Equal(t, 2, requests)
+}
+
+func TestNewClientWithOptions_CacheScope(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"c1","type":"comments","attributes":{"content":"Nice"}}}`)
+	}))
+	defer srv.Close()
+
+	cache := NewMemoryCache(10)
+	newClient := func(token string) *Client {
+		client, err := NewClientWithOptions(WithBaseURL(srv.URL+"/"), WithToken(token), WithCache(cache, time.Minute))
+		if err != nil {
+			t.Fatalf("NewClientWithOptions returned error: %v", err)
+		}
+		return client
+	}
+
+	ctx := context.Background()
+	alice, bob := newClient("alice"), newClient("bob")
+	assert.NotEqual(t, alice.CacheScope, bob.CacheScope)
+	assert.Equal(t, alice.CacheScope, newClient("alice").CacheScope)
+
+	_, res, err := alice.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+
+	// Responses fetched with other credentials are not reused.
+	_, res, err = bob.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+
+	_, res, err = newClient("alice").Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, res.CacheStatus)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClient_CacheInvalidation_evicted(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.Cache = NewMemoryCache(1)
+	client.CacheTTL = time.Minute
+
+	mux.HandleFunc("/comments/c1/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			fmt.Fprint(w, `{"data":{"id":"c1","type":"comments","attributes":{"content":"Nicer"}}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"c1","type":"comments","attributes":{"content":"Nice"}}}`)
+	})
+	mux.HandleFunc("/comments/c2/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"c2","type":"comments","attributes":{"content":"Fine"}}}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Comments.GetCommentByID(ctx, "c2")
+	assert.NoError(t, err)
+
+	// The update of c1 invalidates c2 although the cache only holds one entry.
+	_, _, err = client.Comments.UpdateComment(ctx, "c1", "Nicer")
+	assert.NoError(t, err)
+	_, res, err := client.Comments.GetCommentByID(ctx, "c2")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+}
+
+func TestClient_CacheScope_unset(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	cache := NewMemoryCache(10)
+	client.Cache = cache
+	client.CacheTTL = time.Minute
+
+	mux.HandleFunc("/comments/c1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"c1","type":"comments","attributes":{"content":"Nice"}}}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	_, res, err := client.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheHit, res.CacheStatus)
+
+	// Without scope, clients sharing a cache do not share its entries.
+	other := NewClient(nil)
+	other.BaseURL = client.BaseURL
+	other.Cache = cache
+	other.CacheTTL = time.Minute
+	_, res, err = other.Comments.GetCommentByID(ctx, "c1")
+	assert.NoError(t, err)
+	assert.Equal(t, CacheMiss, res.CacheStatus)
+}
+
+func TestClient_Cache_sensitive(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.Cache = NewMemoryCache(10)
+	client.CacheTTL = time.Minute
+
+	mux.HandleFunc("/tokens/t1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"t1","type":"tokens","attributes":{"name":"CLI","token_id":"secret"}}}`)
+	})
+	mux.HandleFunc("/applications/a1/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{"data":{"id":"a1","type":"applications","attributes":{"name":"App","client_secret":"secret"}}}`)
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, res, err := client.Tokens.GetTokenByID(ctx, "t1")
+		assert.NoError(t, err)
+		assert.NotEqual(t, CacheHit, res.CacheStatus)
+		_, appRes, err := client.Applications.GetApplicationByID(ctx, "a1")
+		assert.NoError(t, err)
+		assert.NotEqual(t, CacheHit, appRes.CacheStatus)
+	}
+	assert.Equal(t, 0, client.Cache.(*MemoryCache).Len())
+}
+
+func TestMemoryCache_Eviction(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2)
+
+	cache.Set(ctx, "a", []byte("1"))
+	cache.Set(ctx, "b", []byte("2"))
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", []byte("3"))
+
+	b, _ := cache.Get(ctx, "b")
+	assert.Nil(t, b)
+	a, _ := cache.Get(ctx, "a")
+	assert.Equal(t, []byte("1"), a)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestDiskCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewDiskCache(t.TempDir())
+
+	v, err := cache.Get(ctx, "https://api.osf.io/v2/preprints/xfdsr/")
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	assert.NoError(t, cache.Set(ctx, "https://api.osf.io/v2/preprints/xfdsr/", []byte("payload")))
+	v, err = cache.Get(ctx, "https://api.osf.io/v2/preprints/xfdsr/")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("payload"), v)
+
+	assert.NoError(t, cache.Delete(ctx, "https://api.osf.io/v2/preprints/xfdsr/"))
+	assert.NoError(t, cache.Delete(ctx, "https://api.osf.io/v2/preprints/xfdsr/"))
+}
@@ -0,0 +1,86 @@
+package osf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CheckpointStore persists the progress of long-running operations, such as
+// PreprintWatcher, so that they can resume where they stopped.
+type CheckpointStore interface {
+	// Load returns the checkpoint saved under key, or nil if there is none.
+	Load(ctx context.Context, key string) ([]byte, error)
+	// Save saves a checkpoint under key, replacing the previous one.
+	Save(ctx context.Context, key string, checkpoint []byte) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore keeping checkpoints in memory.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string][]byte
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string][]byte{}}
+}
+
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoints[key], nil
+}
+
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key string, checkpoint []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[key] = append([]byte(nil), checkpoint...)
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore keeping each checkpoint in a file of
+// a directory. Files are replaced atomically, so a crash never leaves a
+// partially written checkpoint.
+type FileCheckpointStore struct {
+	Dir string
+}
+
+func NewFileCheckpointStore(dir string) *FileCheckpointStore {
+	return &FileCheckpointStore{Dir: dir}
+}
+
+func (s *FileCheckpointStore) path(key string) string {
+	return filepath.Join(s.Dir, strings.NewReplacer("/", "_", "\\", "_").Replace(key)+".json")
+}
+
+func (s *FileCheckpointStore) Load(ctx context.Context, key string) ([]byte, error) {
+	b, err := os.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func (s *FileCheckpointStore) Save(ctx context.Context, key string, checkpoint []byte) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.Dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(checkpoint); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(key))
+}
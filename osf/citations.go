@@ -0,0 +1,325 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Common styles for GetPreprintCitationStyle. Any CSL style ID known to OSF can be used.
+const (
+	CitationStyleAPA     = "apa"
+	CitationStyleMLA     = "modern-language-association"
+	CitationStyleChicago = "chicago-author-date"
+)
+
+// CSLName is a name in CSL-JSON (https://citeproc-js.readthedocs.io/en/latest/csl-json/markup.html).
+type CSLName struct {
+	Given   string `json:"given,omitempty"`
+	Family  string `json:"family,omitempty"`
+	Literal string `json:"literal,omitempty"`
+}
+
+func (n *CSLName) String() string {
+	if n.Literal != "" {
+		return n.Literal
+	}
+	if n.Given == "" {
+		return n.Family
+	}
+	if n.Family == "" {
+		return n.Given
+	}
+	return n.Family + ", " + n.Given
+}
+
+// CSLDate is a date in CSL-JSON, made of [year, month, day] parts.
+type CSLDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+func (d *CSLDate) part(i int) int {
+	if d == nil || len(d.DateParts) == 0 || len(d.DateParts[0]) <= i {
+		return 0
+	}
+	return d.DateParts[0][i]
+}
+
+// CSLKeywords are the keywords of a citation. CSL-JSON holds them in a single
+// string, which they are joined into, while a keyword string read from
+// CSL-JSON is kept as a single keyword since its separator is unknown.
+type CSLKeywords []string
+
+func (k CSLKeywords) String() string {
+	return strings.Join(k, ", ")
+}
+
+func (k CSLKeywords) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+func (k *CSLKeywords) UnmarshalJSON(b []byte) error {
+	var keywords []string
+	if err := json.Unmarshal(b, &keywords); err == nil {
+		*k = keywords
+		return nil
+	}
+
+	var keyword string
+	if err := json.Unmarshal(b, &keyword); err != nil {
+		return err
+	}
+	*k = nil
+	if keyword != "" {
+		*k = CSLKeywords{keyword}
+	}
+	return nil
+}
+
+// Citation is a CSL-JSON item, as returned by /preprints/{id}/citation/ or built
+// offline with NewPreprintCitation.
+type Citation struct {
+	ID string `json:"id"`
+
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Author    []*CSLName  `json:"author,omitempty"`
+	Publisher string      `json:"publisher,omitempty"`
+	Issued    *CSLDate    `json:"issued,omitempty"`
+	DOI       string      `json:"DOI,omitempty"`
+	URL       string      `json:"URL,omitempty"`
+	Abstract  string      `json:"abstract,omitempty"`
+	Keyword   CSLKeywords `json:"keyword,omitempty"`
+}
+
+// StyledCitation is a citation rendered by OSF in a given style.
+type StyledCitation struct {
+	ID string `json:"id"`
+
+	Citation string `json:"citation"`
+}
+
+func (s *PreprintsService) GetPreprintCitation(ctx context.Context, id string) (*Citation, *SinglePayload[*Citation, interface{}], error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("preprints/%s/citation/", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle[*Citation, interface{}](s.client, ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// GetPreprintCitationStyle returns the citation of a preprint rendered by OSF in style, e.g. CitationStyleAPA.
+func (s *PreprintsService) GetPreprintCitationStyle(ctx context.Context, id string, style string) (*StyledCitation, *SinglePayload[*StyledCitation, interface{}], error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("preprints/%s/citation/%s/", id, style), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle[*StyledCitation, interface{}](s.client, ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// NewPreprintCitation builds a citation offline from a preprint and its
+// contributors, as listed by ListPreprintContributors. Only bibliographic
+// contributors are cited, in their index order. The preprint DOI is preferred
+// over the DOI of the published article.
+func NewPreprintCitation(preprint *Preprint, contributors []*Contributor, publisher string) *Citation {
+	c := &Citation{
+		ID:        preprint.ID,
+		Type:      "article",
+		Title:     preprint.Title,
+		Publisher: publisher,
+		Abstract:  preprint.Description,
+		Keyword:   CSLKeywords(preprint.Tags),
+	}
+
+	sorted := make([]*Contributor, len(contributors))
+	copy(sorted, contributors)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+	for _, contributor := range sorted {
+		if !contributor.Bibliographic {
+			continue
+		}
+		switch {
+		case contributor.User != nil && contributor.User.FamilyName != "":
+			given := strings.TrimSpace(contributor.User.GivenName + " " + contributor.User.MiddleNames)
+			c.Author = append(c.Author, &CSLName{Given: given, Family: contributor.User.FamilyName})
+		case contributor.User != nil:
+			c.Author = append(c.Author, &CSLName{Literal: contributor.User.FullName})
+		case contributor.UnregisteredContributor != nil:
+			c.Author = append(c.Author, &CSLName{Literal: *contributor.UnregisteredContributor})
+		}
+	}
+
+	date := preprint.DatePublished
+	if date == nil {
+		date = preprint.DateCreated
+	}
+	if date != nil {
+		c.Issued = &CSLDate{DateParts: [][]int{{date.Year(), int(date.Month()), date.Day()}}}
+	}
+
+	if preprint.Links != nil {
+		if preprint.Links.PreprintDOI != nil {
+			c.DOI = trimDOI(*preprint.Links.PreprintDOI)
+		}
+		if preprint.Links.Html != nil {
+			c.URL = *preprint.Links.Html
+		}
+	}
+	if c.DOI == "" && preprint.DOI != nil {
+		c.DOI = trimDOI(*preprint.DOI)
+	}
+
+	return c
+}
+
+// trimDOI strips the resolver prefix of a DOI URL.
+func trimDOI(doi string) string {
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		doi = strings.TrimPrefix(doi, prefix)
+	}
+	return doi
+}
+
+// FormatCSLJSON renders citations as a CSL-JSON array.
+func FormatCSLJSON(citations ...*Citation) ([]byte, error) {
+	if citations == nil {
+		citations = []*Citation{}
+	}
+	return json.MarshalIndent(citations, "", "  ")
+}
+
+// FormatBibTeX renders citations as BibTeX entries.
+func FormatBibTeX(citations ...*Citation) string {
+	var b strings.Builder
+	for i, c := range citations {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		entryType := "misc"
+		if c.Type == "article-journal" {
+			entryType = "article"
+		}
+		fmt.Fprintf(&b, "@%s{%s,\n", entryType, bibtexKey(c))
+
+		field := func(name, value string) {
+			if value != "" {
+				fmt.Fprintf(&b, "  %s = {%s},\n", name, value)
+			}
+		}
+		field("title", bibtexEscape(c.Title))
+		authors := make([]string, 0, len(c.Author))
+		for _, author := range c.Author {
+			authors = append(authors, bibtexEscape(author.String()))
+		}
+		field("author", strings.Join(authors, " and "))
+		if year := c.Issued.part(0); year != 0 {
+			field("year", strconv.Itoa(year))
+		}
+		if month := c.Issued.part(1); month != 0 {
+			field("month", strconv.Itoa(month))
+		}
+		field("publisher", bibtexEscape(c.Publisher))
+		field("doi", c.DOI)
+		field("url", c.URL)
+		field("keywords", bibtexEscape(c.Keyword.String()))
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// bibtexKey builds a citation key such as "doe2022", falling back to the item ID.
+func bibtexKey(c *Citation) string {
+	var key strings.Builder
+	if len(c.Author) > 0 {
+		name := c.Author[0].Family
+		if name == "" {
+			name = c.Author[0].Literal
+		}
+		for _, r := range strings.ToLower(name) {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				key.WriteRune(r)
+			}
+		}
+	}
+	if key.Len() == 0 {
+		return c.ID
+	}
+	if year := c.Issued.part(0); year != 0 {
+		key.WriteString(strconv.Itoa(year))
+	}
+	return key.String()
+}
+
+var bibtexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+)
+
+func bibtexEscape(s string) string {
+	return bibtexReplacer.Replace(s)
+}
+
+// FormatRIS renders citations as RIS records.
+func FormatRIS(citations ...*Citation) string {
+	var b strings.Builder
+	for _, c := range citations {
+		tag := func(name, value string) {
+			if value != "" {
+				fmt.Fprintf(&b, "%s  - %s\n", name, strings.ReplaceAll(value, "\n", " "))
+			}
+		}
+
+		risType := "GEN"
+		switch c.Type {
+		case "article-journal":
+			risType = "JOUR"
+		case "book":
+			risType = "BOOK"
+		case "dataset":
+			risType = "DATA"
+		}
+		tag("TY", risType)
+		for _, author := range c.Author {
+			tag("AU", author.String())
+		}
+		tag("TI", c.Title)
+		if year := c.Issued.part(0); year != 0 {
+			tag("PY", strconv.Itoa(year))
+			if month, day := c.Issued.part(1), c.Issued.part(2); month != 0 && day != 0 {
+				tag("DA", fmt.Sprintf("%04d/%02d/%02d", year, month, day))
+			}
+		}
+		tag("PB", c.Publisher)
+		tag("DO", c.DOI)
+		tag("UR", c.URL)
+		tag("AB", c.Abstract)
+		for _, keyword := range c.Keyword {
+			tag("KW", keyword)
+		}
+		b.WriteString("ER  - \n")
+	}
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreprintsService_GetPreprintCitationStyle(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/preprints/xfdsr/citation/apa/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"id":"apa","type":"styled-citations","attributes":{"citation":"Doe, J. (2022). Paper."}}}`)
+	})
+
+	citation, _, err := client.Preprints.GetPreprintCitationStyle(context.Background(), "xfdsr", CitationStyleAPA)
+	if err != nil {
+		t.Fatalf("Preprints.GetPreprintCitationStyle returned error: %v", err)
+	}
+	assert.Equal(t, "apa", citation.ID)
+	assert.Equal(t, "Doe, J. (2022). Paper.", citation.Citation)
+}
+
+func TestNewPreprintCitation(t *testing.T) {
+	preprint := &Preprint{
+		ID:            "xfdsr",
+		Title:         "Search & Games",
+		Tags:          []string{"minimax", "expectimax", "alpha, beta pruning"},
+		DatePublished: &Time{time.Date(2022, time.May, 3, 0, 0, 0, 0, time.UTC)},
+		Links: &PreprintLinks{
+			Html:        StringPointer("https://osf.io/xfdsr"),
+			PreprintDOI: StringPointer("https://doi.org/10.31219/osf.io/xfdsr"),
+		},
+	}
+	contributors := []*Contributor{
+		{Index: 1, Bibliographic: true, User: &User{FullName: "Richard Roe", GivenName: "Richard", FamilyName: "Roe"}},
+		{Index: 0, Bibliographic: true, User: &User{FullName: "Jane Doe", GivenName: "Jane", FamilyName: "Doe"}},
+		{Index: 2, Bibliographic: false, User: &User{FullName: "Hidden Helper"}},
+	}
+
+	citation := NewPreprintCitation(preprint, contributors, "OSF Preprints")
+	assert.Equal(t, "10.31219/osf.io/xfdsr", citation.DOI)
+	assert.Equal(t, []*CSLName{{Given: "Jane", Family: "Doe"}, {Given: "Richard", Family: "Roe"}}, citation.Author)
+
+	assert.Equal(t, `@misc{doe2022,
+  title = {Search \& Games},
+  author = {Doe, Jane and Roe, Richard},
+  year = {2022},
+  month = {5},
+  publisher = {OSF Preprints},
+  doi = {10.31219/osf.io/xfdsr},
+  url = {https://osf.io/xfdsr},
+  keywords = {minimax, expectimax, alpha, beta pruning},
+}
+`, FormatBibTeX(citation))
+
+	assert.Equal(t, `TY  - GEN
+AU  - Doe, Jane
+AU  - Roe, Richard
+TI  - Search & Games
+PY  - 2022
+DA  - 2022/05/03
+PB  - OSF Preprints
+DO  - 10.31219/osf.io/xfdsr
+UR  - https://osf.io/xfdsr
+KW  - minimax
+KW  - expectimax
+KW  - alpha, beta pruning
+ER  - 
+`, FormatRIS(citation))
+
+	b, err := FormatCSLJSON(citation)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"DOI": "10.31219/osf.io/xfdsr"`)
+	assert.Contains(t, string(b), `"date-parts": [`)
+	assert.Contains(t, string(b), `"keyword": "minimax, expectimax, alpha, beta pruning"`)
+
+	var read Citation
+	assert.NoError(t, json.Unmarshal([]byte(`{"keyword":"minimax, expectimax"}`), &read))
+	assert.Equal(t, CSLKeywords{"minimax, expectimax"}, read.Keyword)
+}
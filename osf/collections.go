@@ -0,0 +1,351 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Triggers of ModerateCollectionSubmission.
+const (
+	CollectionSubmissionAccept   = "accept"
+	CollectionSubmissionReject   = "reject"
+	CollectionSubmissionRemove   = "remove"
+	CollectionSubmissionResubmit = "resubmit"
+)
+
+type CollectionsService service
+
+type CollectionLinks struct {
+	Self *string `json:"self"`
+}
+
+type Collection struct {
+	ID string `json:"id"`
+
+	Title                string   `json:"title"`
+	DateCreated          *Time    `json:"date_created"`
+	DateModified         *Time    `json:"date_modified"`
+	Bookmarks            bool     `json:"bookmarks"`
+	IsPromoted           bool     `json:"is_promoted"`
+	IsPublic             bool     `json:"is_public"`
+	StatusChoices        []string `json:"status_choices"`
+	CollectedTypeChoices []string `json:"collected_type_choices"`
+	VolumeChoices        []string `json:"volume_choices"`
+	IssueChoices         []string `json:"issue_choices"`
+	ProgramAreaChoices   []string `json:"program_area_choices"`
+	SchoolTypeChoices    []string `json:"school_type_choices"`
+	StudyDesignChoices   []string `json:"study_design_choices"`
+
+	// ProviderID is the ID of the collection provider moderating this collection, if any.
+	ProviderID *string `json:"-"`
+
+	Links *CollectionLinks `json:"-"`
+}
+
+type CollectionRequest struct {
+	Title                *string   `json:"title,omitempty"`
+	IsPublic             *bool     `json:"is_public,omitempty"`
+	StatusChoices        *[]string `json:"status_choices,omitempty"`
+	CollectedTypeChoices *[]string `json:"collected_type_choices,omitempty"`
+	VolumeChoices        *[]string `json:"volume_choices,omitempty"`
+	IssueChoices         *[]string `json:"issue_choices,omitempty"`
+	ProgramAreaChoices   *[]string `json:"program_area_choices,omitempty"`
+	SchoolTypeChoices    *[]string `json:"school_type_choices,omitempty"`
+	StudyDesignChoices   *[]string `json:"study_design_choices,omitempty"`
+}
+
+// CollectionSubmission is an item collected in a collection, along with its
+// collection-specific metadata.
+type CollectionSubmission struct {
+	ID string `json:"id"`
+
+	CollectedType string `json:"collected_type"`
+	Status        string `json:"status"`
+	Volume        string `json:"volume"`
+	Issue         string `json:"issue"`
+	ProgramArea   string `json:"program_area"`
+	SchoolType    string `json:"school_type"`
+	StudyDesign   string `json:"study_design"`
+	ReviewsState  string `json:"reviews_state"`
+
+	// GUID is the GUID of the collected node, registration or preprint.
+	GUID string `json:"-"`
+
+	Links *CollectionLinks `json:"-"`
+}
+
+type CollectionSubmissionRequest struct {
+	// GUID is the GUID of the item to collect. It is only used on submission.
+	GUID string `json:"-"`
+
+	CollectedType *string `json:"collected_type,omitempty"`
+	Status        *string `json:"status,omitempty"`
+	Volume        *string `json:"volume,omitempty"`
+	Issue         *string `json:"issue,omitempty"`
+	ProgramArea   *string `json:"program_area,omitempty"`
+	SchoolType    *string `json:"school_type,omitempty"`
+	StudyDesign   *string `json:"study_design,omitempty"`
+}
+
+type CollectionProvider struct {
+	ID string `json:"id"`
+
+	Name                    string                 `json:"name"`
+	Description             string                 `json:"description"`
+	AdvisoryBoard           *string                `json:"advisory_board"`
+	Example                 *string                `json:"example"`
+	Domain                  *string                `json:"domain"`
+	DomainRedirectEnabled   bool                   `json:"domain_redirect_enabled"`
+	FooterLinks             *string                `json:"footer_links"`
+	EmailSupport            *string                `json:"email_support"`
+	AllowSubmissions        bool                   `json:"allow_submissions"`
+	AllowCommenting         bool                   `json:"allow_commenting"`
+	Assets                  map[string]interface{} `json:"assets"`
+	ReviewsWorkflow         *string                `json:"reviews_workflow"`
+	ReviewsCommentPrivate   bool                   `json:"reviews_comment_private"`
+	ReviewsCommentAnonymous bool                   `json:"reviews_comment_anonymous"`
+	Permissions             []string               `json:"permissions"`
+
+	// PrimaryCollectionID is the ID of the collection the provider moderates.
+	PrimaryCollectionID *string `json:"-"`
+
+	Links *CollectionLinks `json:"-"`
+}
+
+// CollectionSubmissionAction is a moderation action taken on a collection submission.
+type CollectionSubmissionAction struct {
+	ID string `json:"id"`
+
+	Trigger      string `json:"trigger"`
+	Comment      string `json:"comment"`
+	FromState    string `json:"from_state"`
+	ToState      string `json:"to_state"`
+	DateCreated  *Time  `json:"date_created"`
+	DateModified *Time  `json:"date_modified"`
+}
+
+type collectionSubmissionActionRequest struct {
+	Trigger string `json:"trigger"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type CollectionsListOptions struct {
+	ListOptions
+}
+
+func transformCollection(raw *Data[*Collection, *CollectionLinks]) (*Collection, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["provider"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.ProviderID = rel.Data.ID
+	}
+	return obj, nil
+}
+
+func transformCollectionSubmission(raw *Data[*CollectionSubmission, *CollectionLinks]) (*CollectionSubmission, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["guid"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.GUID = *rel.Data.ID
+	}
+	return obj, nil
+}
+
+func transformCollectionProvider(raw *Data[*CollectionProvider, *CollectionLinks]) (*CollectionProvider, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["primary_collection"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.PrimaryCollectionID = rel.Data.ID
+	}
+	return obj, nil
+}
+
+func (s *CollectionsService) ListCollections(ctx context.Context, opts *CollectionsListOptions) ([]*Collection, *ManyPayload[*Collection, *CollectionLinks], error) {
+	return listResources(s.client, ctx, "collections/", opts, transformCollection)
+}
+
+func (s *CollectionsService) GetCollectionByID(ctx context.Context, id string) (*Collection, *SinglePayload[*Collection, *CollectionLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("collections/%s/", id), transformCollection)
+}
+
+func (s *CollectionsService) CreateCollection(ctx context.Context, input *CollectionRequest) (*Collection, *SinglePayload[*Collection, *CollectionLinks], error) {
+	body := &SinglePayload[*CollectionRequest, interface{}]{
+		Data: &Data[*CollectionRequest, interface{}]{
+			Type:       TypeCollections,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "collections/", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformCollection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *CollectionsService) UpdateCollection(ctx context.Context, id string, input *CollectionRequest) (*Collection, *SinglePayload[*Collection, *CollectionLinks], error) {
+	body := &SinglePayload[*CollectionRequest, interface{}]{
+		Data: &Data[*CollectionRequest, interface{}]{
+			Type:       TypeCollections,
+			ID:         &id,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("collections/%s/", id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformCollection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *CollectionsService) DeleteCollection(ctx context.Context, id string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("collections/%s/", id), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
+
+// ListCollectionNodes lists the nodes collected in a collection.
+func (s *CollectionsService) ListCollectionNodes(ctx context.Context, id string, opts *ListOptions) ([]*Node, *ManyPayload[*Node, *NodeLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("collections/%s/linked_nodes/", id), opts, transformNode)
+}
+
+// ListCollectionRegistrations lists the registrations collected in a collection.
+func (s *CollectionsService) ListCollectionRegistrations(ctx context.Context, id string, opts *ListOptions) ([]*Registration, *ManyPayload[*Registration, *RegistrationLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("collections/%s/linked_registrations/", id), opts, transformRegistration)
+}
+
+// ListCollectionPreprints lists the preprints collected in a collection.
+func (s *CollectionsService) ListCollectionPreprints(ctx context.Context, id string, opts *ListOptions) ([]*Preprint, *ManyPayload[*Preprint, *PreprintLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("collections/%s/linked_preprints/", id), opts, transformPreprint)
+}
+
+// ListCollectionSubmissions lists the items of a collection with their collection-specific metadata.
+// Filter on metadata fields, e.g. "status" or "reviews_state", through opts.Filter.
+func (s *CollectionsService) ListCollectionSubmissions(ctx context.Context, id string, opts *ListOptions) ([]*CollectionSubmission, *ManyPayload[*CollectionSubmission, *CollectionLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("collections/%s/collected_metadata/", id), opts, transformCollectionSubmission)
+}
+
+// GetCollectionSubmission gets the metadata of an item of a collection. submissionID is the GUID of the item.
+func (s *CollectionsService) GetCollectionSubmission(ctx context.Context, collectionID string, submissionID string) (*CollectionSubmission, *SinglePayload[*CollectionSubmission, *CollectionLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("collections/%s/collected_metadata/%s/", collectionID, submissionID), transformCollectionSubmission)
+}
+
+// SubmitToCollection adds the item identified by input.GUID to a collection.
+// On moderated collections, the submission is pending until it is accepted.
+func (s *CollectionsService) SubmitToCollection(ctx context.Context, collectionID string, input *CollectionSubmissionRequest) (*CollectionSubmission, *SinglePayload[*CollectionSubmission, *CollectionLinks], error) {
+	body := &SinglePayload[*CollectionSubmissionRequest, interface{}]{
+		Data: &Data[*CollectionSubmissionRequest, interface{}]{
+			Type:       TypeCollectedMetadata,
+			Attributes: input,
+			Relationships: Relationships{
+				"guid": Relationship{
+					Data: &Data[interface{}, interface{}]{
+						ID:   &input.GUID,
+						Type: TypeGuids,
+					},
+				},
+			},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("collections/%s/collected_metadata/", collectionID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformCollectionSubmission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *CollectionsService) UpdateCollectionSubmission(ctx context.Context, collectionID string, submissionID string, input *CollectionSubmissionRequest) (*CollectionSubmission, *SinglePayload[*CollectionSubmission, *CollectionLinks], error) {
+	body := &SinglePayload[*CollectionSubmissionRequest, interface{}]{
+		Data: &Data[*CollectionSubmissionRequest, interface{}]{
+			Type:       TypeCollectedMetadata,
+			ID:         &submissionID,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("collections/%s/collected_metadata/%s/", collectionID, submissionID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformCollectionSubmission)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// RemoveCollectionSubmission removes an item from a collection.
+func (s *CollectionsService) RemoveCollectionSubmission(ctx context.Context, collectionID string, submissionID string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("collections/%s/collected_metadata/%s/", collectionID, submissionID), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
+
+func (s *CollectionsService) ListCollectionProviders(ctx context.Context, opts *ListOptions) ([]*CollectionProvider, *ManyPayload[*CollectionProvider, *CollectionLinks], error) {
+	return listResources(s.client, ctx, "providers/collections/", opts, transformCollectionProvider)
+}
+
+func (s *CollectionsService) GetCollectionProviderByID(ctx context.Context, id string) (*CollectionProvider, *SinglePayload[*CollectionProvider, *CollectionLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("providers/collections/%s/", id), transformCollectionProvider)
+}
+
+// ModerateCollectionSubmission takes a moderation action, e.g. CollectionSubmissionAccept,
+// on the submission of the item guid to a moderated collection.
+func (s *CollectionsService) ModerateCollectionSubmission(ctx context.Context, collectionID string, guid string, trigger string, comment string) (*CollectionSubmissionAction, *SinglePayload[*CollectionSubmissionAction, interface{}], error) {
+	submissionID := guid + "-" + collectionID
+	body := &SinglePayload[*collectionSubmissionActionRequest, interface{}]{
+		Data: &Data[*collectionSubmissionActionRequest, interface{}]{
+			Type:       TypeCollectionSubmissionActions,
+			Attributes: &collectionSubmissionActionRequest{Trigger: trigger, Comment: comment},
+			Relationships: Relationships{
+				"target": Relationship{
+					Data: &Data[interface{}, interface{}]{
+						ID:   &submissionID,
+						Type: TypeCollectionSubmissions,
+					},
+				},
+			},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "collection_submission_actions/", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle[*CollectionSubmissionAction, interface{}](s.client, ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
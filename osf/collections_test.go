@@ -0,0 +1,65 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionsService_SubmitToCollection(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/collections/col1/collected_metadata/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body SinglePayload[*CollectionSubmissionRequest, interface{}]
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, TypeCollectedMetadata, body.Data.Type)
+		assert.Equal(t, "abc12", *body.Data.Relationships["guid"].Data.ID)
+		assert.Equal(t, "Replication", *body.Data.Attributes.CollectedType)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"abc12","type":"collected-metadata",
+			"attributes":{"collected_type":"Replication","status":"Complete","reviews_state":"pending"},
+			"relationships":{"guid":{"data":{"id":"abc12","type":"guids"}}}}}`)
+	})
+	mux.HandleFunc("/collection_submission_actions/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body SinglePayload[*collectionSubmissionActionRequest, interface{}]
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, CollectionSubmissionAccept, body.Data.Attributes.Trigger)
+		assert.Equal(t, "abc12-col1", *body.Data.Relationships["target"].Data.ID)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"act1","type":"collection-submission-actions",
+			"attributes":{"trigger":"accept","from_state":"pending","to_state":"accepted"}}}`)
+	})
+
+	ctx := context.Background()
+	submission, _, err := client.Collections.SubmitToCollection(ctx, "col1", &CollectionSubmissionRequest{
+		GUID:          "abc12",
+		CollectedType: StringPointer("Replication"),
+		Status:        StringPointer("Complete"),
+	})
+	if err != nil {
+		t.Fatalf("Collections.SubmitToCollection returned error: %v", err)
+	}
+	assert.Equal(t, "abc12", submission.GUID)
+	assert.Equal(t, "pending", submission.ReviewsState)
+
+	action, _, err := client.Collections.ModerateCollectionSubmission(ctx, "col1", "abc12", CollectionSubmissionAccept, "")
+	if err != nil {
+		t.Fatalf("Collections.ModerateCollectionSubmission returned error: %v", err)
+	}
+	assert.Equal(t, "accepted", action.ToState)
+}
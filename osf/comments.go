@@ -0,0 +1,221 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Target types of a comment.
+const (
+	CommentTargetNode    = TypeNodes
+	CommentTargetFile    = TypeFiles
+	CommentTargetWiki    = "wiki"
+	CommentTargetComment = TypeComments
+)
+
+// Categories of ReportComment.
+const (
+	CommentReportSpam     = "spam"
+	CommentReportHate     = "hate"
+	CommentReportViolence = "violence"
+)
+
+type CommentsService service
+
+type CommentLinks struct {
+	Self *string `json:"self"`
+}
+
+type Comment struct {
+	ID string `json:"id"`
+
+	Content      string `json:"content"`
+	Page         string `json:"page"`
+	DateCreated  *Time  `json:"date_created"`
+	DateModified *Time  `json:"date_modified"`
+	Modified     bool   `json:"modified"`
+	Deleted      bool   `json:"deleted"`
+	IsAbuse      bool   `json:"is_abuse"`
+	HasReport    bool   `json:"has_report"`
+	HasChildren  bool   `json:"has_children"`
+	CanEdit      bool   `json:"can_edit"`
+
+	// TargetID and TargetType identify what the comment is about: a node, a
+	// file, a wiki page, or another comment for replies.
+	TargetID   string `json:"-"`
+	TargetType string `json:"-"`
+	UserID     string `json:"-"`
+	NodeID     string `json:"-"`
+
+	Links *CommentLinks `json:"-"`
+}
+
+// CommentThread is a comment along with its replies, recursively.
+type CommentThread struct {
+	Comment *Comment
+	Replies []*CommentThread
+}
+
+type CommentReport struct {
+	ID string `json:"id,omitempty"`
+
+	Category string `json:"category"`
+	Message  string `json:"message"`
+}
+
+type commentRequest struct {
+	Content *string `json:"content,omitempty"`
+	Deleted *bool   `json:"deleted,omitempty"`
+}
+
+type CommentsListOptions struct {
+	ListOptions
+}
+
+func transformComment(raw *Data[*Comment, *CommentLinks]) (*Comment, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["target"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.TargetID = *rel.Data.ID
+		obj.TargetType = rel.Data.Type
+	}
+	if rel, ok := raw.Relationships["user"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.UserID = *rel.Data.ID
+	}
+	if rel, ok := raw.Relationships["node"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.NodeID = *rel.Data.ID
+	}
+	return obj, nil
+}
+
+// ListNodeComments lists the comments of a node and of its files and wiki pages.
+// Filter on "target" to only list the comments about a node, file, wiki page or comment.
+func (s *CommentsService) ListNodeComments(ctx context.Context, nodeID string, opts *CommentsListOptions) ([]*Comment, *ManyPayload[*Comment, *CommentLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("nodes/%s/comments/", nodeID), opts, transformComment)
+}
+
+// GetCommentThreads returns the comments about targetID, which may be the node
+// itself, one of its files or wiki pages, along with all their replies.
+func (s *CommentsService) GetCommentThreads(ctx context.Context, nodeID string, targetID string) ([]*CommentThread, error) {
+	comments, err := listAllResources(s.client, ctx, fmt.Sprintf("nodes/%s/comments/", nodeID), map[string]string{"target": targetID}, transformComment)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := make([]*CommentThread, 0, len(comments))
+	for _, comment := range comments {
+		thread := &CommentThread{Comment: comment}
+		if comment.HasChildren {
+			thread.Replies, err = s.GetCommentThreads(ctx, nodeID, comment.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		threads = append(threads, thread)
+	}
+
+	return threads, nil
+}
+
+func (s *CommentsService) GetCommentByID(ctx context.Context, id string) (*Comment, *SinglePayload[*Comment, *CommentLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("comments/%s/", id), transformComment)
+}
+
+// CreateComment posts a comment on a node about the target of type targetType,
+// e.g. CommentTargetFile, identified by targetID.
+func (s *CommentsService) CreateComment(ctx context.Context, nodeID string, targetType string, targetID string, content string) (*Comment, *SinglePayload[*Comment, *CommentLinks], error) {
+	body := &SinglePayload[*commentRequest, interface{}]{
+		Data: &Data[*commentRequest, interface{}]{
+			Type:       TypeComments,
+			Attributes: &commentRequest{Content: &content},
+			Relationships: Relationships{
+				"target": Relationship{
+					Data: &Data[interface{}, interface{}]{
+						ID:   &targetID,
+						Type: targetType,
+					},
+				},
+			},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("nodes/%s/comments/", nodeID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformComment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// ReplyToComment posts a reply to a comment of a node.
+func (s *CommentsService) ReplyToComment(ctx context.Context, nodeID string, commentID string, content string) (*Comment, *SinglePayload[*Comment, *CommentLinks], error) {
+	return s.CreateComment(ctx, nodeID, CommentTargetComment, commentID, content)
+}
+
+func (s *CommentsService) updateComment(ctx context.Context, id string, input *commentRequest) (*Comment, *SinglePayload[*Comment, *CommentLinks], error) {
+	body := &SinglePayload[*commentRequest, interface{}]{
+		Data: &Data[*commentRequest, interface{}]{
+			Type:       TypeComments,
+			ID:         &id,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("comments/%s/", id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformComment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *CommentsService) UpdateComment(ctx context.Context, id string, content string) (*Comment, *SinglePayload[*Comment, *CommentLinks], error) {
+	return s.updateComment(ctx, id, &commentRequest{Content: &content})
+}
+
+// DeleteComment marks a comment as deleted. It can be restored with UndeleteComment.
+func (s *CommentsService) DeleteComment(ctx context.Context, id string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("comments/%s/", id), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
+
+func (s *CommentsService) UndeleteComment(ctx context.Context, id string) (*Comment, *SinglePayload[*Comment, *CommentLinks], error) {
+	return s.updateComment(ctx, id, &commentRequest{Deleted: BoolPointer(false)})
+}
+
+// ReportComment reports a comment as abusive, with a category such as CommentReportSpam.
+func (s *CommentsService) ReportComment(ctx context.Context, id string, category string, message string) (*CommentReport, *SinglePayload[*CommentReport, interface{}], error) {
+	body := &SinglePayload[*CommentReport, interface{}]{
+		Data: &Data[*CommentReport, interface{}]{
+			Type:       TypeCommentReports,
+			Attributes: &CommentReport{Category: category, Message: message},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("comments/%s/reports/", id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle[*CommentReport, interface{}](s.client, ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
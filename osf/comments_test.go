@@ -0,0 +1,48 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentsService_GetCommentThreads(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/abc12/comments/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch target := r.URL.Query().Get("filter[target]"); target {
+		case "abc12":
+			fmt.Fprint(w, `{"data":[
+				{"id":"c1","type":"comments","attributes":{"content":"Nice","has_children":true,"date_created":"2022-05-03T10:00:00.000000"},
+				 "relationships":{"target":{"data":{"id":"abc12","type":"nodes"}}}},
+				{"id":"c2","type":"comments","attributes":{"content":"Typo in methods"}}
+			],"links":{"meta":{"total":2,"per_page":100}}}`)
+		case "c1":
+			fmt.Fprint(w, `{"data":[
+				{"id":"c3","type":"comments","attributes":{"content":"Thanks"},
+				 "relationships":{"target":{"data":{"id":"c1","type":"comments"}}}}
+			],"links":{"meta":{"total":1,"per_page":100}}}`)
+		default:
+			t.Errorf("unexpected target %q", target)
+		}
+	})
+
+	threads, err := client.Comments.GetCommentThreads(context.Background(), "abc12", "abc12")
+	if err != nil {
+		t.Fatalf("Comments.GetCommentThreads returned error: %v", err)
+	}
+
+	assert.Len(t, threads, 2)
+	assert.Equal(t, "c1", threads[0].Comment.ID)
+	assert.Equal(t, CommentTargetNode, threads[0].Comment.TargetType)
+	assert.Equal(t, 2022, threads[0].Comment.DateCreated.Year())
+	assert.Len(t, threads[0].Replies, 1)
+	assert.Equal(t, "c3", threads[0].Replies[0].Comment.ID)
+	assert.Equal(t, "c1", threads[0].Replies[0].Comment.TargetID)
+	assert.Empty(t, threads[1].Replies)
+}
@@ -0,0 +1,133 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Values of Contributor.Permission.
+const (
+	PermissionRead  = "read"
+	PermissionWrite = "write"
+	PermissionAdmin = "admin"
+)
+
+type Contributor struct {
+	ID string `json:"id"`
+
+	Bibliographic           bool    `json:"bibliographic"`
+	Permission              string  `json:"permission"`
+	Index                   int     `json:"index"`
+	UnregisteredContributor *string `json:"unregistered_contributor"`
+
+	// UserID is the ID of the contributing user.
+	UserID string `json:"-"`
+	// User is only set when the user was embedded in the response.
+	User *User `json:"-"`
+
+	Links *ContributorLinks `json:"-"`
+}
+
+type ContributorLinks struct {
+	Self *string `json:"self"`
+}
+
+// ContributorRequest is the input of contributor updates. Nil fields are left unchanged.
+type ContributorRequest struct {
+	Permission    *string `json:"permission,omitempty"`
+	Bibliographic *bool   `json:"bibliographic,omitempty"`
+}
+
+// ContributorUpdate is the update of one of the contributors of BulkUpdateNodeContributors.
+type ContributorUpdate struct {
+	UserID string
+	Input  *ContributorRequest
+}
+
+type ContributorsListOptions struct {
+	ListOptions
+}
+
+func transformContributor(raw *Data[*Contributor, *ContributorLinks]) (*Contributor, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["users"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.UserID = *rel.Data.ID
+	}
+
+	user, err := decodeEmbed(raw.Embeds, "users", transformUser)
+	if err != nil {
+		return nil, err
+	}
+	obj.User = user
+
+	return obj, nil
+}
+
+// ListPreprintContributors lists the contributors of a preprint, with their user embedded.
+func (s *PreprintsService) ListPreprintContributors(ctx context.Context, id string, opts *ContributorsListOptions) ([]*Contributor, *ManyPayload[*Contributor, *ContributorLinks], error) {
+	return listResources(s.client, ctx, appendQuery(fmt.Sprintf("preprints/%s/contributors/", id), "embed", "users"), opts, transformContributor)
+}
+
+// ListNodeContributors lists the contributors of a node, with their user embedded.
+func (s *NodesService) ListNodeContributors(ctx context.Context, nodeID string, opts *ContributorsListOptions) ([]*Contributor, *ManyPayload[*Contributor, *ContributorLinks], error) {
+	return listResources(s.client, ctx, appendQuery(fmt.Sprintf("nodes/%s/contributors/", nodeID), "embed", "users"), opts, transformContributor)
+}
+
+// nodeContributorsPayload builds the bulk payload of the contributors userIDs of a node, whose IDs are "{nodeID}-{userID}".
+func nodeContributorsPayload(nodeID string, userIDs []string, inputs []*ContributorRequest) *BulkPayload[*ContributorRequest] {
+	body := &BulkPayload[*ContributorRequest]{Data: make([]*Data[*ContributorRequest, interface{}], 0, len(userIDs))}
+	for i, userID := range userIDs {
+		id := nodeID + "-" + userID
+		data := &Data[*ContributorRequest, interface{}]{Type: TypeContributors, ID: &id}
+		if inputs != nil {
+			data.Attributes = inputs[i]
+		}
+		body.Data = append(body.Data, data)
+	}
+	return body
+}
+
+// BulkUpdateNodeContributors updates up to 100 contributors of a node in one
+// request, e.g. to change their permissions. Either every update is applied or
+// none is; the results, identified by user ID, tell which updates failed.
+func (s *NodesService) BulkUpdateNodeContributors(ctx context.Context, nodeID string, updates []*ContributorUpdate) ([]*BulkResult[*Contributor], error) {
+	userIDs := make([]string, 0, len(updates))
+	inputs := make([]*ContributorRequest, 0, len(updates))
+	for _, update := range updates {
+		userIDs = append(userIDs, update.UserID)
+		inputs = append(inputs, update.Input)
+	}
+
+	body := nodeContributorsPayload(nodeID, userIDs, inputs)
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("nodes/%s/contributors/", nodeID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := doBulk(s.client, ctx, req, body.ids(), transformContributor)
+	return identifyByUserID(results, userIDs), err
+}
+
+// BulkDeleteNodeContributors removes up to 100 contributors from a node in one
+// request. The results are identified by user ID and their values are nil.
+func (s *NodesService) BulkDeleteNodeContributors(ctx context.Context, nodeID string, userIDs ...string) ([]*BulkResult[*Contributor], error) {
+	body := nodeContributorsPayload(nodeID, userIDs, nil)
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("nodes/%s/contributors/", nodeID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := doBulkNoContent[*Contributor](s.client, ctx, req, body.ids())
+	return identifyByUserID(results, userIDs), err
+}
+
+// identifyByUserID identifies the results of a bulk request on contributors,
+// which are in the order of userIDs, by user ID instead of contributor ID.
+func identifyByUserID(results []*BulkResult[*Contributor], userIDs []string) []*BulkResult[*Contributor] {
+	for i, result := range results {
+		result.ID = userIDs[i]
+	}
+	return results
+}
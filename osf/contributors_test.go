@@ -0,0 +1,61 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const contributorsPayload = `{"data":[{
+	"id":"p1-u1","type":"contributors",
+	"attributes":{"bibliographic":true,"permission":"admin","index":0},
+	"relationships":{"users":{"data":{"id":"u1","type":"users"}}},
+	"embeds":{"users":{"data":{"id":"u1","type":"users","attributes":{"full_name":"Ada Lovelace"}}}}
+}],"links":{"meta":{"total":1,"per_page":10}}}`
+
+func TestPreprintsService_ListPreprintContributors(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/preprints/p1/contributors/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "users", r.URL.Query().Get("embed"))
+		assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+		fmt.Fprint(w, contributorsPayload)
+	})
+
+	contributors, _, err := client.Preprints.ListPreprintContributors(context.Background(), "p1", &ContributorsListOptions{ListOptions: ListOptions{Page: 2}})
+	if err != nil {
+		t.Fatalf("Preprints.ListPreprintContributors returned error: %v", err)
+	}
+
+	if assert.Len(t, contributors, 1) {
+		assert.Equal(t, "u1", contributors[0].UserID)
+		if assert.NotNil(t, contributors[0].User) {
+			assert.Equal(t, "Ada Lovelace", contributors[0].User.FullName)
+		}
+	}
+}
+
+func TestNodesService_ListNodeContributors(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/n1/contributors/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "users", r.URL.Query().Get("embed"))
+		fmt.Fprint(w, contributorsPayload)
+	})
+
+	contributors, _, err := client.Nodes.ListNodeContributors(context.Background(), "n1", nil)
+	if err != nil {
+		t.Fatalf("Nodes.ListNodeContributors returned error: %v", err)
+	}
+
+	if assert.Len(t, contributors, 1) && assert.NotNil(t, contributors[0].User) {
+		assert.Equal(t, "Ada Lovelace", contributors[0].User.FullName)
+	}
+}
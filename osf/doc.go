@@ -25,6 +25,22 @@ Usage:
 
 The OSF API token can be obtained from https://osf.io/settings/tokens.
 
+Alternatively, auth.NewClient of github.com/joshuabezaleel/go-osf/auth finds
+the token the way cloud SDKs do: from the OSF_API_TOKEN environment variable,
+a config file with named profiles, or an encrypted credentials store.
+
+	client, err := auth.NewClient(ctx, nil)
+
+NewClientWithOptions configures the client up front, failing on invalid settings:
+
+	client, err := osf.NewClientWithOptions(
+		osf.WithToken("... your access token ..."),
+		osf.WithEnvironment(osf.EnvironmentTest),
+		osf.WithUserAgent("my-tool/1.0"),
+		osf.WithRetryPolicy(osf.DefaultRetryPolicy),
+		osf.WithRateLimit(5, 10),
+	)
+
 Response Schema
 
 OSF API conforms the JSON API spec v1.0 (https://jsonapi.org/format/1.0/). The
@@ -93,6 +109,15 @@ and PaginationMeta field will contain the pagination info.
 		opts.Page = res.PaginationMeta.Page+1
 	}
 
+Logging
+
+Client.Logger, or the WithLogger option, logs every request with its method,
+URL with secrets redacted, status, duration, retries and JSON:API errors. A
+*slog.Logger can be used as is, and LoggerFuncs and LoggerFunc adapt zap,
+logrus and others:
+
+	client.Logger = slog.Default()
+
 Error Handling
 
 You may check the error returned for type *Errors, which contains more verbose information
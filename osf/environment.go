@@ -0,0 +1,52 @@
+package osf
+
+import "fmt"
+
+// Environment is a deployment of the OSF, such as production or one of its
+// testing servers, which have separate accounts and data.
+type Environment struct {
+	Name string
+
+	// BaseURL is the URL of the API, with a trailing slash.
+	BaseURL string
+	// AccountsURL is the URL of the accounts server issuing OAuth2 tokens, with a trailing slash.
+	AccountsURL string
+}
+
+var (
+	EnvironmentProduction = &Environment{
+		Name:        "production",
+		BaseURL:     defaultBaseURL,
+		AccountsURL: "https://accounts.osf.io/",
+	}
+	EnvironmentStaging = &Environment{
+		Name:        "staging",
+		BaseURL:     "https://api.staging.osf.io/v2/",
+		AccountsURL: "https://accounts.staging.osf.io/",
+	}
+	EnvironmentTest = &Environment{
+		Name:        "test",
+		BaseURL:     defaultBaseTestURL,
+		AccountsURL: "https://accounts.test.osf.io/",
+	}
+)
+
+// Environments are the known environments, by name.
+var Environments = map[string]*Environment{
+	EnvironmentProduction.Name: EnvironmentProduction,
+	EnvironmentStaging.Name:    EnvironmentStaging,
+	EnvironmentTest.Name:       EnvironmentTest,
+}
+
+// EnvironmentByName returns the known environment named name, defaulting to
+// production if name is empty.
+func EnvironmentByName(name string) (*Environment, error) {
+	if name == "" {
+		return EnvironmentProduction, nil
+	}
+	env, ok := Environments[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown OSF environment %q", name)
+	}
+	return env, nil
+}
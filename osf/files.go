@@ -83,18 +83,29 @@ func (s *FilesService) DownloadFile(ctx context.Context, dir string, filename st
 	}
 	defer out.Close()
 
-	req, err := http.NewRequest("GET", *file.FileLinks.Download, nil)
+	u := *file.FileLinks.Download
+	if s.client.ViewOnlyKey != "" {
+		u = appendQuery(u, "view_only", s.client.ViewOnlyKey)
+	}
+
+	req, err := http.NewRequest("GET", u, nil)
 	if err != nil {
 		return err
 	}
 
-	res, err := s.client.client.Do(req)
+	res, err := s.client.execute(ctx, req)
 	if err != nil {
-		return err
+		return requestError(err)
 	}
 	defer res.Body.Close()
 
-	_, err = io.Copy(out, res.Body)
+	total := res.ContentLength
+	if total < 0 {
+		total = file.Size
+	}
+	body := newProgressReader(res.Body, s.client.progressReporter(ctx), TransferDownload, filename, total)
+
+	_, err = io.Copy(out, body)
 	if err != nil {
 		return err
 	}
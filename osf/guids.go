@@ -0,0 +1,209 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// guidPattern matches an OSF GUID, optionally followed by a preprint version suffix such as "_v2".
+var guidPattern = regexp.MustCompile(`^[a-z0-9]{5}(_v[0-9]+)?$`)
+
+type GuidsService service
+
+// Guid maps an OSF GUID, the 5-character ID found in URLs such as
+// https://osf.io/xfdsr, to the resource it refers to.
+type Guid struct {
+	ID string `json:"id"`
+
+	// ReferentType is the type of the resource, e.g. TypePreprints or TypeNodes.
+	ReferentType string `json:"-"`
+	ReferentID   string `json:"-"`
+}
+
+// GuidReferent is the typed resource a GUID refers to. Exactly one of the
+// resource fields, according to Type, is set.
+type GuidReferent struct {
+	GUID string
+	Type string
+	ID   string
+
+	Node         *Node
+	Registration *Registration
+	Preprint     *Preprint
+	File         *File
+	User         *User
+}
+
+func transformGuid(raw *Data[*Guid, interface{}]) (*Guid, error) {
+	obj := raw.Attributes
+	if obj == nil {
+		obj = &Guid{}
+	}
+	if raw.ID != nil {
+		obj.ID = *raw.ID
+	}
+
+	rel, ok := raw.Relationships["referent"]
+	if !ok {
+		return nil, fmt.Errorf("guid %s has no referent", obj.ID)
+	}
+	if rel.Data != nil {
+		obj.ReferentType = rel.Data.Type
+		if rel.Data.ID != nil {
+			obj.ReferentID = *rel.Data.ID
+		}
+	} else if rel.Links != nil && rel.Links.Related != nil {
+		if typ, ok := rel.Links.Related.Meta["type"].(string); ok {
+			obj.ReferentType = typ
+		}
+	}
+	if obj.ReferentID == "" {
+		obj.ReferentID = obj.ID
+	}
+
+	return obj, nil
+}
+
+// GetGuid returns the type and ID of the resource a GUID refers to, without fetching it.
+func (s *GuidsService) GetGuid(ctx context.Context, guid string) (*Guid, *SinglePayload[*Guid, interface{}], error) {
+	return getResource(s.client, ctx, appendQuery(fmt.Sprintf("guids/%s/", guid), "resolve", "false"), transformGuid)
+}
+
+// Resource returns the resource field of r which is set.
+func (r *GuidReferent) Resource() interface{} {
+	switch {
+	case r.Node != nil:
+		return r.Node
+	case r.Registration != nil:
+		return r.Registration
+	case r.Preprint != nil:
+		return r.Preprint
+	case r.File != nil:
+		return r.File
+	case r.User != nil:
+		return r.User
+	}
+	return nil
+}
+
+// ParseGUID extracts the GUID of a string which may be a bare GUID ("xfdsr"),
+// an OSF URL ("https://osf.io/xfdsr/", "https://osf.io/preprints/psyarxiv/xfdsr",
+// "https://api.osf.io/v2/nodes/xfdsr/") or an OSF-minted DOI, with or without
+// resolver ("https://doi.org/10.31234/osf.io/xfdsr", "10.17605/OSF.IO/XFDSR").
+// The version suffix of a preprint GUID, as in "xfdsr_v2", is dropped.
+func ParseGUID(s string) (string, error) {
+	guid, _, err := parseGUID(s)
+	return guid, err
+}
+
+// parseGUID is ParseGUID, also returning the version suffix of the GUID, e.g. "_v2".
+func parseGUID(s string) (string, string, error) {
+	in := strings.TrimSpace(s)
+	if in == "" {
+		return "", "", fmt.Errorf("empty guid")
+	}
+
+	doi := trimDOI(in)
+	if strings.HasPrefix(doi, "10.") {
+		i := strings.Index(strings.ToLower(doi), "osf.io/")
+		if i == -1 {
+			return "", "", fmt.Errorf("%q is not a DOI minted by OSF", s)
+		}
+		return checkGUID(s, doi[i+len("osf.io/"):])
+	}
+
+	if !strings.Contains(in, "/") {
+		return checkGUID(s, in)
+	}
+
+	if !strings.Contains(in, "://") {
+		in = "https://" + in
+	}
+	u, err := url.Parse(in)
+	if err != nil {
+		return "", "", fmt.Errorf("%q is not a valid OSF URL: %w", s, err)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	switch {
+	case len(segments) >= 3 && segments[0] == "preprints" && guidPattern.MatchString(strings.ToLower(segments[2])):
+		// /preprints/{provider}/{guid}
+		return checkGUID(s, segments[2])
+	case len(segments) >= 2 && (segments[0] == "preprints" || segments[0] == "download"):
+		// /preprints/{guid}, /preprints/{guid}/download, /download/{guid}
+		return checkGUID(s, segments[1])
+	case len(segments) >= 3 && segments[0] == "v2":
+		// /v2/{type}/{guid}
+		return checkGUID(s, segments[2])
+	case len(segments) >= 1:
+		return checkGUID(s, segments[0])
+	}
+	return "", "", fmt.Errorf("no guid found in %q", s)
+}
+
+func checkGUID(s string, guid string) (string, string, error) {
+	guid = strings.ToLower(strings.Trim(guid, "/"))
+	if !guidPattern.MatchString(guid) {
+		return "", "", fmt.Errorf("no guid found in %q", s)
+	}
+	guid, version := splitGUIDVersion(guid)
+	return guid, version, nil
+}
+
+// splitGUIDVersion splits a preprint GUID such as "xfdsr_v2" into its base
+// GUID and its version suffix.
+func splitGUIDVersion(guid string) (string, string) {
+	if i := strings.Index(guid, "_v"); i != -1 {
+		return guid[:i], guid[i:]
+	}
+	return guid, ""
+}
+
+// Resolve fetches the resource a GUID refers to, through the service of its
+// type. str can be anything accepted by ParseGUID, such as an OSF URL or DOI.
+// The version of a preprint GUID, as in "xfdsr_v2", is kept, so that the
+// preprint fetched is that version.
+func (s *GuidsService) Resolve(ctx context.Context, str string) (*GuidReferent, error) {
+	guid, version, err := parseGUID(str)
+	if err != nil {
+		return nil, err
+	}
+
+	g, _, err := s.GetGuid(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	referent := &GuidReferent{GUID: g.ID, Type: g.ReferentType, ID: g.ReferentID}
+	if g.ReferentType == TypePreprints && version != "" {
+		base, _ := splitGUIDVersion(g.ReferentID)
+		referent.ID = base + version
+	}
+	switch g.ReferentType {
+	case TypeNodes:
+		referent.Node, _, err = s.client.Nodes.GetNodeByID(ctx, g.ReferentID)
+	case TypeRegistrations:
+		referent.Registration, _, err = s.client.Registrations.GetRegistrationByID(ctx, g.ReferentID)
+	case TypePreprints:
+		referent.Preprint, _, err = s.client.Preprints.GetPreprintByID(ctx, referent.ID)
+	case TypeFiles:
+		referent.File, _, err = s.client.Files.GetFileByID(ctx, g.ReferentID)
+	case TypeUsers:
+		referent.User, _, err = s.client.Users.GetUserByID(ctx, g.ReferentID)
+	default:
+		return nil, fmt.Errorf("guid %s refers to an unsupported resource type %q", guid, g.ReferentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return referent, nil
+}
@@ -0,0 +1,93 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuidsService_Resolve(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/guids/xfdsr/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "false", r.URL.Query().Get("resolve"))
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"guids",
+			"relationships":{"referent":{"data":{"id":"xfdsr","type":"preprints"}}}}}`)
+	})
+	mux.HandleFunc("/preprints/xfdsr", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"preprints","attributes":{"title":"Paper"}}}`)
+	})
+
+	referent, err := client.Guids.Resolve(context.Background(), "https://osf.io/preprints/psyarxiv/xfdsr/")
+	if err != nil {
+		t.Fatalf("Guids.Resolve returned error: %v", err)
+	}
+	assert.Equal(t, TypePreprints, referent.Type)
+	if assert.NotNil(t, referent.Preprint) {
+		assert.Equal(t, "Paper", referent.Preprint.Title)
+	}
+	assert.Nil(t, referent.Node)
+}
+
+func TestGuidsService_Resolve_preprintVersion(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/guids/xfdsr/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"guids",
+			"relationships":{"referent":{"data":{"id":"xfdsr_v3","type":"preprints"}}}}}`)
+	})
+	mux.HandleFunc("/preprints/xfdsr_v2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":{"id":"xfdsr_v2","type":"preprints","attributes":{"title":"Paper, v2"}}}`)
+	})
+
+	referent, err := client.Guids.Resolve(context.Background(), "osf.io/preprints/psyarxiv/xfdsr_v2")
+	if err != nil {
+		t.Fatalf("Guids.Resolve returned error: %v", err)
+	}
+	assert.Equal(t, "xfdsr", referent.GUID)
+	assert.Equal(t, "xfdsr_v2", referent.ID)
+	if assert.NotNil(t, referent.Preprint) {
+		assert.Equal(t, "xfdsr_v2", referent.Preprint.ID)
+	}
+}
+
+func TestParseGUID(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"xfdsr", "xfdsr"},
+		{" XFDSR ", "xfdsr"},
+		{"osf.io/xfdsr", "xfdsr"},
+		{"https://osf.io/xfdsr/", "xfdsr"},
+		{"https://osf.io/xfdsr/files/osfstorage", "xfdsr"},
+		{"https://osf.io/download/xfdsr/", "xfdsr"},
+		{"https://osf.io/preprints/psyarxiv/xfdsr", "xfdsr"},
+		{"https://osf.io/preprints/psyarxiv/xfdsr_v2", "xfdsr"},
+		{"https://osf.io/preprints/xfdsr_v1/download", "xfdsr"},
+		{"https://api.osf.io/v2/nodes/xfdsr/", "xfdsr"},
+		{"10.31234/osf.io/xfdsr", "xfdsr"},
+		{"https://doi.org/10.17605/OSF.IO/XFDSR", "xfdsr"},
+		{"doi:10.17605/OSF.IO/XFDSR", "xfdsr"},
+	}
+	for _, tt := range tests {
+		got, err := ParseGUID(tt.in)
+		if assert.NoError(t, err, tt.in) {
+			assert.Equal(t, tt.want, got, tt.in)
+		}
+	}
+
+	for _, in := range []string{"", "10.1038/nature12373", "https://osf.io/", "x!"} {
+		_, err := ParseGUID(in)
+		assert.Error(t, err, in)
+	}
+}
@@ -0,0 +1,288 @@
+package osf
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// HarvestPage is a page of resources delivered by a Harvester.
+type HarvestPage[T any] struct {
+	// Number is the number of the page, starting at 1.
+	Number int
+	// Pages is the total number of pages of the harvest.
+	Pages int
+	Items []T
+}
+
+type harvestCheckpoint struct {
+	Total   int   `json:"total"`
+	PerPage int   `json:"per_page"`
+	Done    []int `json:"done"`
+}
+
+// harvestListFn lists a page of resources, along with the pagination of the listing.
+type harvestListFn[T any] func(ctx context.Context, page, perPage int) ([]T, *PaginationMeta, error)
+
+// Harvester fetches every page of a listing. The first page reveals the total
+// number of pages, then the remaining ones are fetched concurrently. Requests
+// wait for Client.RateLimiter, if any, like every other request.
+//
+// With a Store, the pages handled so far are checkpointed, so a harvest which
+// stopped, e.g. on a crash, resumes without fetching them again. The
+// checkpoint is reset once the harvest completes.
+type Harvester[T any] struct {
+	list    harvestListFn[T]
+	perPage int
+	key     string
+
+	// Concurrency is the maximum number of pages fetched at once. Defaults to 4.
+	Concurrency int
+	// Ordered delivers the pages in page order. Otherwise they are delivered as
+	// soon as they are fetched.
+	Ordered bool
+	// Store keeps the checkpoint of the harvest. Harvests are not checkpointed without store.
+	Store CheckpointStore
+	// Key is the key of the checkpoint in the store. Defaults to one derived from the listing.
+	Key string
+}
+
+func newHarvester[T any](key string, perPage int, list harvestListFn[T]) *Harvester[T] {
+	if perPage <= 0 || perPage > 100 {
+		perPage = 100
+	}
+	return &Harvester[T]{list: list, perPage: perPage, key: key, Concurrency: 4}
+}
+
+// harvestKey derives the key of the checkpoint of a harvest from its listing URL u.
+func harvestKey(prefix string, u string) string {
+	sum := sha256.Sum256([]byte(u))
+	return prefix + "-" + hex.EncodeToString(sum[:8])
+}
+
+func (h *Harvester[T]) checkpointKey() string {
+	if h.Key != "" {
+		return h.Key
+	}
+	return h.key
+}
+
+func (h *Harvester[T]) loadCheckpoint(ctx context.Context) (*harvestCheckpoint, error) {
+	if h.Store == nil {
+		return nil, nil
+	}
+
+	b, err := h.Store.Load(ctx, h.checkpointKey())
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+
+	cp := &harvestCheckpoint{}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+func (h *Harvester[T]) saveCheckpoint(ctx context.Context, cp *harvestCheckpoint) error {
+	if h.Store == nil {
+		return nil
+	}
+
+	var b []byte
+	if cp != nil {
+		var err error
+		if b, err = json.Marshal(cp); err != nil {
+			return err
+		}
+	}
+	return h.Store.Save(ctx, h.checkpointKey(), b)
+}
+
+type harvestResult[T any] struct {
+	page  int
+	items []T
+	err   error
+}
+
+// Run harvests the listing and calls fn for each page, until every page is
+// handled, ctx is done or fn returns an error. fn is never called concurrently.
+// A page is only checkpointed once fn has handled it, so pages are delivered
+// at least once.
+func (h *Harvester[T]) Run(ctx context.Context, fn func(page *HarvestPage[T]) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cp, err := h.loadCheckpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	done := map[int]bool{}
+	var first []T
+	if cp != nil {
+		for _, page := range cp.Done {
+			done[page] = true
+		}
+	}
+	if !done[1] {
+		items, meta, err := h.list(ctx, 1, h.perPage)
+		if err != nil {
+			return err
+		}
+		first = items
+
+		// A resumed harvest keeps the pagination of its checkpoint, so that pages keep the same items.
+		if cp == nil {
+			cp = &harvestCheckpoint{PerPage: h.perPage, Total: len(items)}
+			if meta != nil {
+				cp.Total = meta.Total
+				if meta.PerPage > 0 {
+					cp.PerPage = meta.PerPage
+				}
+			}
+		}
+	}
+
+	pages := 1
+	if cp.Total > cp.PerPage {
+		pages = (cp.Total + cp.PerPage - 1) / cp.PerPage
+	}
+
+	deliver := func(page int, items []T) error {
+		if err := fn(&HarvestPage[T]{Number: page, Pages: pages, Items: items}); err != nil {
+			return err
+		}
+		cp.Done = append(cp.Done, page)
+		sort.Ints(cp.Done)
+		return h.saveCheckpoint(ctx, cp)
+	}
+
+	if !done[1] {
+		if err := deliver(1, first); err != nil {
+			return err
+		}
+	}
+
+	var remaining []int
+	for page := 2; page <= pages; page++ {
+		if !done[page] {
+			remaining = append(remaining, page)
+		}
+	}
+
+	concurrency := h.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	// Buffered so that fetches never block, even once Run has returned.
+	results := make(chan *harvestResult[T], concurrency)
+	fetch := func(page int) {
+		items, _, err := h.list(ctx, page, cp.PerPage)
+		results <- &harvestResult[T]{page: page, items: items, err: err}
+	}
+
+	// Pages of remaining before next are dispatched, those before delivered are
+	// delivered. Ordered harvests buffer the pages fetched ahead of delivered,
+	// which are at most twice the concurrency.
+	next, delivered, inflight := 0, 0, 0
+	fetched := map[int][]T{}
+	for delivered < len(remaining) {
+		for inflight < concurrency && next < len(remaining) && (!h.Ordered || next-delivered < 2*concurrency) {
+			go fetch(remaining[next])
+			next++
+			inflight++
+		}
+
+		var res *harvestResult[T]
+		select {
+		case res = <-results:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		inflight--
+		if res.err != nil {
+			return res.err
+		}
+
+		if !h.Ordered {
+			if err := deliver(res.page, res.items); err != nil {
+				return err
+			}
+			delivered++
+			continue
+		}
+
+		fetched[res.page] = res.items
+		for delivered < len(remaining) {
+			items, ok := fetched[remaining[delivered]]
+			if !ok {
+				break
+			}
+			delete(fetched, remaining[delivered])
+			if err := deliver(remaining[delivered], items); err != nil {
+				return err
+			}
+			delivered++
+		}
+	}
+
+	// Start over on the next run.
+	return h.saveCheckpoint(ctx, nil)
+}
+
+// Pages runs Run in a goroutine and delivers the pages on the returned
+// channel. Both channels are closed when the harvest stops; the error which
+// stopped it, if any other than the cancellation of ctx, is sent on the error
+// channel first.
+func (h *Harvester[T]) Pages(ctx context.Context) (<-chan *HarvestPage[T], <-chan error) {
+	pages := make(chan *HarvestPage[T])
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(pages)
+
+		err := h.Run(ctx, func(page *HarvestPage[T]) error {
+			select {
+			case pages <- page:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return pages, errs
+}
+
+// NewHarvester returns a Harvester of the preprints listed with opts, e.g. of
+// every preprint of a provider. The page of opts is ignored and its page size
+// defaults to 100.
+func (s *PreprintsService) NewHarvester(opts *PreprintsListOptions) *Harvester[*Preprint] {
+	base := PreprintsListOptions{}
+	if opts != nil {
+		base = *opts
+	}
+	perPage := base.PerPage
+	base.Page, base.PerPage = 0, 0
+
+	// An invalid listing fails on the first page anyway.
+	u, _ := addOptionsWithFilter("preprints", &base, base.Filter)
+
+	return newHarvester(harvestKey("preprint-harvest", u), perPage, func(ctx context.Context, page, perPage int) ([]*Preprint, *PaginationMeta, error) {
+		opts := base
+		opts.Page, opts.PerPage = page, perPage
+		preprints, res, err := s.ListPreprints(ctx, &opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return preprints, res.PaginationMeta, nil
+	})
+}
@@ -0,0 +1,131 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// harvestServer serves 9 preprints in pages of 2 and records the pages requested.
+func harvestServer(t *testing.T, mux *http.ServeMux) func() []int {
+	var mu sync.Mutex
+	var requested []int
+	mux.HandleFunc("/preprints", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "psyarxiv", r.URL.Query().Get("filter[provider]"))
+		assert.Equal(t, "2", r.URL.Query().Get("page[size]"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+
+		mu.Lock()
+		requested = append(requested, page)
+		mu.Unlock()
+
+		var data []string
+		for i := (page-1)*2 + 1; i <= page*2 && i <= 9; i++ {
+			data = append(data, fmt.Sprintf(`{"id":"p%d","type":"preprints","attributes":{}}`, i))
+		}
+		fmt.Fprintf(w, `{"data":[%s],"links":{"meta":{"total":9,"per_page":2}}}`, strings.Join(data, ","))
+	})
+
+	return func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), requested...)
+	}
+}
+
+func TestHarvester_Run(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	requested := harvestServer(t, mux)
+
+	opts := &PreprintsListOptions{ListOptions: ListOptions{PerPage: 2, Filter: map[string]string{"provider": "psyarxiv"}}}
+	harvester := client.Preprints.NewHarvester(opts)
+	harvester.Concurrency = 2
+	harvester.Ordered = true
+
+	var pages []int
+	var ids []string
+	err := harvester.Run(context.Background(), func(page *HarvestPage[*Preprint]) error {
+		assert.Equal(t, 5, page.Pages)
+		pages = append(pages, page.Number)
+		for _, preprint := range page.Items {
+			ids = append(ids, preprint.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Harvester.Run returned error: %v", err)
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, pages)
+	assert.Equal(t, []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8", "p9"}, ids)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4, 5}, requested())
+}
+
+func TestHarvester_Run_unordered(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	harvestServer(t, mux)
+
+	opts := &PreprintsListOptions{ListOptions: ListOptions{PerPage: 2, Filter: map[string]string{"provider": "psyarxiv"}}}
+	pages, errs := client.Preprints.NewHarvester(opts).Pages(context.Background())
+	var ids []string
+	for page := range pages {
+		for _, preprint := range page.Items {
+			ids = append(ids, preprint.ID)
+		}
+	}
+	assert.NoError(t, <-errs)
+	assert.ElementsMatch(t, []string{"p1", "p2", "p3", "p4", "p5", "p6", "p7", "p8", "p9"}, ids)
+}
+
+func TestHarvester_Run_resume(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	requested := harvestServer(t, mux)
+
+	store := NewMemoryCheckpointStore()
+	opts := &PreprintsListOptions{ListOptions: ListOptions{PerPage: 2, Filter: map[string]string{"provider": "psyarxiv"}}}
+	newHarvester := func() *Harvester[*Preprint] {
+		harvester := client.Preprints.NewHarvester(opts)
+		harvester.Concurrency = 1
+		harvester.Ordered = true
+		harvester.Store = store
+		return harvester
+	}
+
+	crash := errors.New("crash")
+	err := newHarvester().Run(context.Background(), func(page *HarvestPage[*Preprint]) error {
+		if page.Number == 3 {
+			return crash
+		}
+		return nil
+	})
+	assert.Equal(t, crash, err)
+	assert.Equal(t, []int{1, 2, 3}, requested()[:3])
+
+	// The harvest resumes at the page which was not handled.
+	before := len(requested())
+	var pages []int
+	err = newHarvester().Run(context.Background(), func(page *HarvestPage[*Preprint]) error {
+		pages = append(pages, page.Number)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Harvester.Run returned error: %v", err)
+	}
+	assert.Equal(t, []int{3, 4, 5}, pages)
+	assert.Equal(t, []int{3, 4, 5}, requested()[before:])
+
+	// A completed harvest starts over.
+	b, _ := store.Load(context.Background(), newHarvester().checkpointKey())
+	assert.Empty(t, b)
+}
@@ -0,0 +1,100 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Values of Identifier.Category.
+const (
+	IdentifierCategoryDOI = "doi"
+	IdentifierCategoryARK = "ark"
+)
+
+type IdentifiersService service
+
+type IdentifierLinks struct {
+	Self *string `json:"self"`
+}
+
+// Identifier is a persistent identifier, such as a DOI, minted for a node, a
+// registration or a preprint.
+type Identifier struct {
+	ID string `json:"id,omitempty"`
+
+	Category string `json:"category"`
+	Value    string `json:"value,omitempty"`
+
+	// ReferentID and ReferentType identify the resource the identifier was minted for.
+	ReferentID   string `json:"-"`
+	ReferentType string `json:"-"`
+
+	Links *IdentifierLinks `json:"-"`
+}
+
+type IdentifiersListOptions struct {
+	ListOptions
+}
+
+func transformIdentifier(raw *Data[*Identifier, *IdentifierLinks]) (*Identifier, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["referent"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.ReferentID = *rel.Data.ID
+		obj.ReferentType = rel.Data.Type
+	}
+	return obj, nil
+}
+
+// ListNodeIdentifiers lists the identifiers of a node. Filter on "category" through opts.Filter.
+func (s *IdentifiersService) ListNodeIdentifiers(ctx context.Context, nodeID string, opts *IdentifiersListOptions) ([]*Identifier, *ManyPayload[*Identifier, *IdentifierLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("nodes/%s/identifiers/", nodeID), opts, transformIdentifier)
+}
+
+// ListRegistrationIdentifiers lists the identifiers of a registration. Filter on "category" through opts.Filter.
+func (s *IdentifiersService) ListRegistrationIdentifiers(ctx context.Context, registrationID string, opts *IdentifiersListOptions) ([]*Identifier, *ManyPayload[*Identifier, *IdentifierLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("registrations/%s/identifiers/", registrationID), opts, transformIdentifier)
+}
+
+// ListPreprintIdentifiers lists the identifiers of a preprint. Filter on "category" through opts.Filter.
+func (s *IdentifiersService) ListPreprintIdentifiers(ctx context.Context, preprintID string, opts *IdentifiersListOptions) ([]*Identifier, *ManyPayload[*Identifier, *IdentifierLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("preprints/%s/identifiers/", preprintID), opts, transformIdentifier)
+}
+
+func (s *IdentifiersService) GetIdentifierByID(ctx context.Context, id string) (*Identifier, *SinglePayload[*Identifier, *IdentifierLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("identifiers/%s/", id), transformIdentifier)
+}
+
+func (s *IdentifiersService) createIdentifier(ctx context.Context, u string, category string) (*Identifier, *SinglePayload[*Identifier, *IdentifierLinks], error) {
+	body := &SinglePayload[*Identifier, interface{}]{
+		Data: &Data[*Identifier, interface{}]{
+			Type:       TypeIdentifiers,
+			Attributes: &Identifier{Category: category},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformIdentifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// CreateNodeIdentifier requests OSF to mint an identifier of category, e.g.
+// IdentifierCategoryDOI, for a node. The node must be public.
+func (s *IdentifiersService) CreateNodeIdentifier(ctx context.Context, nodeID string, category string) (*Identifier, *SinglePayload[*Identifier, *IdentifierLinks], error) {
+	return s.createIdentifier(ctx, fmt.Sprintf("nodes/%s/identifiers/", nodeID), category)
+}
+
+// CreateRegistrationIdentifier requests OSF to mint an identifier of category,
+// e.g. IdentifierCategoryDOI, for a registration. The registration must be public.
+func (s *IdentifiersService) CreateRegistrationIdentifier(ctx context.Context, registrationID string, category string) (*Identifier, *SinglePayload[*Identifier, *IdentifierLinks], error) {
+	return s.createIdentifier(ctx, fmt.Sprintf("registrations/%s/identifiers/", registrationID), category)
+}
@@ -0,0 +1,30 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifiersService_CreateNodeIdentifier(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/abc12/identifiers/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"id1","type":"identifiers","attributes":{"category":"doi","value":"10.17605/OSF.IO/ABC12"},
+			"relationships":{"referent":{"data":{"id":"abc12","type":"nodes"}}}}}`)
+	})
+
+	identifier, _, err := client.Identifiers.CreateNodeIdentifier(context.Background(), "abc12", IdentifierCategoryDOI)
+	if err != nil {
+		t.Fatalf("Identifiers.CreateNodeIdentifier returned error: %v", err)
+	}
+	assert.Equal(t, "id1", identifier.ID)
+	assert.Equal(t, "10.17605/OSF.IO/ABC12", identifier.Value)
+	assert.Equal(t, "abc12", identifier.ReferentID)
+}
@@ -0,0 +1,111 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type InstitutionsService service
+
+type InstitutionAssets struct {
+	Logo        *string `json:"logo"`
+	LogoRounded *string `json:"logo_rounded"`
+	Banner      *string `json:"banner"`
+}
+
+type InstitutionLinks struct {
+	Self *string `json:"self"`
+	Html *string `json:"html"`
+}
+
+type Institution struct {
+	ID string `json:"id"`
+
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	LogoPath    *string            `json:"logo_path"`
+	Assets      *InstitutionAssets `json:"assets"`
+	// AuthURL is the single sign-on (SSO) login URL of the institution, if it supports SSO.
+	AuthURL                           *string  `json:"auth_url"`
+	IRI                               *string  `json:"iri"`
+	RORIRI                            *string  `json:"ror_iri"`
+	IRIs                              []string `json:"iris"`
+	InstitutionalRequestAccessEnabled bool     `json:"institutional_request_access_enabled"`
+
+	Links *InstitutionLinks `json:"-"`
+}
+
+// HasSSO reports whether users of the institution can log in through single sign-on.
+func (i *Institution) HasSSO() bool {
+	return i.AuthURL != nil && *i.AuthURL != ""
+}
+
+type InstitutionsListOptions struct {
+	ListOptions
+}
+
+func transformInstitution(raw *Data[*Institution, *InstitutionLinks]) (*Institution, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func (s *InstitutionsService) ListInstitutions(ctx context.Context, opts *InstitutionsListOptions) ([]*Institution, *ManyPayload[*Institution, *InstitutionLinks], error) {
+	return listResources(s.client, ctx, "institutions/", opts, transformInstitution)
+}
+
+func (s *InstitutionsService) GetInstitutionByID(ctx context.Context, id string) (*Institution, *SinglePayload[*Institution, *InstitutionLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("institutions/%s/", id), transformInstitution)
+}
+
+// ListInstitutionNodes lists the public nodes affiliated with an institution.
+func (s *InstitutionsService) ListInstitutionNodes(ctx context.Context, id string, opts *ListOptions) ([]*Node, *ManyPayload[*Node, *NodeLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("institutions/%s/nodes/", id), opts, transformNode)
+}
+
+// ListInstitutionRegistrations lists the public registrations affiliated with an institution.
+func (s *InstitutionsService) ListInstitutionRegistrations(ctx context.Context, id string, opts *ListOptions) ([]*Registration, *ManyPayload[*Registration, *RegistrationLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("institutions/%s/registrations/", id), opts, transformRegistration)
+}
+
+// ListInstitutionPreprints lists the preprints affiliated with an institution.
+func (s *InstitutionsService) ListInstitutionPreprints(ctx context.Context, id string, opts *ListOptions) ([]*Preprint, *ManyPayload[*Preprint, *PreprintLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("institutions/%s/preprints/", id), opts, transformPreprint)
+}
+
+// ListInstitutionUsers lists the users affiliated with an institution.
+func (s *InstitutionsService) ListInstitutionUsers(ctx context.Context, id string, opts *ListOptions) ([]*User, *ManyPayload[*User, *UserLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("institutions/%s/users/", id), opts, transformUser)
+}
+
+func (s *InstitutionsService) updateAffiliations(ctx context.Context, method string, resourceType string, resourceID string, institutionIDs []string) error {
+	u := fmt.Sprintf("%s/%s/relationships/institutions/", resourceType, resourceID)
+
+	req, err := s.client.NewRequest(method, u, newRelationshipsPayload(TypeInstitutions, institutionIDs...))
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
+
+// AddNodeInstitutions affiliates a node with institutions. The current user must be affiliated with them.
+func (s *InstitutionsService) AddNodeInstitutions(ctx context.Context, nodeID string, institutionIDs ...string) error {
+	return s.updateAffiliations(ctx, http.MethodPost, TypeNodes, nodeID, institutionIDs)
+}
+
+// RemoveNodeInstitutions removes the affiliations of a node with institutions.
+func (s *InstitutionsService) RemoveNodeInstitutions(ctx context.Context, nodeID string, institutionIDs ...string) error {
+	return s.updateAffiliations(ctx, http.MethodDelete, TypeNodes, nodeID, institutionIDs)
+}
+
+// AddPreprintInstitutions affiliates a preprint with institutions. The current user must be affiliated with them.
+func (s *InstitutionsService) AddPreprintInstitutions(ctx context.Context, preprintID string, institutionIDs ...string) error {
+	return s.updateAffiliations(ctx, http.MethodPost, TypePreprints, preprintID, institutionIDs)
+}
+
+// RemovePreprintInstitutions removes the affiliations of a preprint with institutions.
+func (s *InstitutionsService) RemovePreprintInstitutions(ctx context.Context, preprintID string, institutionIDs ...string) error {
+	return s.updateAffiliations(ctx, http.MethodDelete, TypePreprints, preprintID, institutionIDs)
+}
@@ -0,0 +1,64 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstitutionsService_ListInstitutionUsers(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/institutions/cos/users/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "Doe", r.URL.Query().Get("filter[family_name]"))
+		fmt.Fprint(w, `{"data":[
+			{"id":"u1","type":"users","attributes":{"full_name":"Jane Doe","family_name":"Doe"}}
+		],"links":{"meta":{"total":1,"per_page":10}}}`)
+	})
+
+	users, _, err := client.Institutions.ListInstitutionUsers(context.Background(), "cos", &ListOptions{
+		Filter: map[string]string{"family_name": "Doe"},
+	})
+	if err != nil {
+		t.Fatalf("Institutions.ListInstitutionUsers returned error: %v", err)
+	}
+	assert.Len(t, users, 1)
+	assert.Equal(t, "u1", users[0].ID)
+	assert.Equal(t, "Jane Doe", users[0].FullName)
+}
+
+func TestInstitutionsService_AddNodeInstitutions(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/abc12/relationships/institutions/", func(w http.ResponseWriter, r *http.Request) {
+		var body RelationshipsPayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, body.Data, 2)
+		assert.Equal(t, TypeInstitutions, body.Data[0].Type)
+		assert.Equal(t, "cos", *body.Data[0].ID)
+
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"data":[{"id":"cos","type":"institutions"},{"id":"mit","type":"institutions"}]}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"errors":[{"detail":"You do not have permission to perform this action."}]}`)
+		}
+	})
+
+	ctx := context.Background()
+	assert.NoError(t, client.Institutions.AddNodeInstitutions(ctx, "abc12", "cos", "mit"))
+
+	err := client.Institutions.RemoveNodeInstitutions(ctx, "abc12", "cos", "mit")
+	assert.EqualError(t, err, "You do not have permission to perform this action.")
+}
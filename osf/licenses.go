@@ -0,0 +1,75 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Values of License.RequiredFields.
+const (
+	LicenseFieldYear             = "year"
+	LicenseFieldCopyrightHolders = "copyrightHolders"
+)
+
+type LicensesService service
+
+type License struct {
+	ID string `json:"id"`
+
+	Name           string   `json:"name"`
+	Text           string   `json:"text"`
+	URL            string   `json:"url"`
+	RequiredFields []string `json:"required_fields"`
+
+	Links *LicenseLinks `json:"-"`
+}
+
+type LicenseLinks struct {
+	Self *string `json:"self"`
+}
+
+type LicensesListOptions struct {
+	ListOptions
+}
+
+func transformLicense(raw *Data[*License, *LicenseLinks]) (*License, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func (s *LicensesService) ListLicenses(ctx context.Context, opts *LicensesListOptions) ([]*License, *ManyPayload[*License, *LicenseLinks], error) {
+	return listResources(s.client, ctx, "licenses/", opts, transformLicense)
+}
+
+// ListProviderLicenses lists the licenses a preprint provider accepts.
+func (s *LicensesService) ListProviderLicenses(ctx context.Context, providerID string, opts *LicensesListOptions) ([]*License, *ManyPayload[*License, *LicenseLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("providers/preprints/%s/licenses/", providerID), opts, transformLicense)
+}
+
+func (s *LicensesService) GetLicenseByID(ctx context.Context, id string) (*License, *SinglePayload[*License, *LicenseLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("licenses/%s/", id), transformLicense)
+}
+
+// ValidateLicenseRecord checks that record holds every field required by license.
+func ValidateLicenseRecord(license *License, record *PreprintLicenseRecord) error {
+	var missing []string
+	for _, field := range license.RequiredFields {
+		switch field {
+		case LicenseFieldYear:
+			if record == nil || strings.TrimSpace(record.Year) == "" {
+				missing = append(missing, field)
+			}
+		case LicenseFieldCopyrightHolders:
+			if record == nil || len(record.CopyrightHolders) == 0 {
+				missing = append(missing, field)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("license %q requires %s in the license record", license.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
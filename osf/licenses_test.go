@@ -0,0 +1,67 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreprintsService_UpdatePreprintLicense(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/providers/preprints/osf/licenses/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":"mit","type":"licenses","attributes":{"name":"MIT License","required_fields":["year","copyrightHolders"]}}
+		],"links":{"meta":{"total":1,"per_page":10}}}`)
+	})
+	mux.HandleFunc("/preprints/xfdsr/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PATCH")
+
+		var body SinglePayload[*PreprintRequest, interface{}]
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "mit", *body.Data.Relationships["license"].Data.ID)
+		assert.Equal(t, TypeLicenses, body.Data.Relationships["license"].Data.Type)
+		assert.Equal(t, "2022", body.Data.Attributes.LicenseRecord.Year)
+
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"preprints","attributes":{"title":"Paper"}}}`)
+	})
+
+	ctx := context.Background()
+	licenses, _, err := client.Licenses.ListProviderLicenses(ctx, "osf", nil)
+	if err != nil {
+		t.Fatalf("Licenses.ListProviderLicenses returned error: %v", err)
+	}
+	assert.Len(t, licenses, 1)
+	assert.Equal(t, "mit", licenses[0].ID)
+
+	_, _, err = client.Preprints.UpdatePreprintLicense(ctx, "xfdsr", licenses[0], &PreprintLicenseRecord{Year: "2022"})
+	assert.EqualError(t, err, `license "MIT License" requires copyrightHolders in the license record`)
+
+	preprint, _, err := client.Preprints.UpdatePreprintLicense(ctx, "xfdsr", licenses[0], &PreprintLicenseRecord{
+		Year:             "2022",
+		CopyrightHolders: []string{"Jane Doe"},
+	})
+	if err != nil {
+		t.Fatalf("Preprints.UpdatePreprintLicense returned error: %v", err)
+	}
+	assert.Equal(t, "xfdsr", preprint.ID)
+}
+
+func TestPreprintRequest_relationships(t *testing.T) {
+	provider := Relationships{"provider": Relationship{Data: &Data[interface{}, interface{}]{ID: StringPointer("osf"), Type: "preprint-providers"}}}
+	input := &PreprintRequest{LicenseID: "mit"}
+
+	relationships := input.relationships(provider)
+	assert.Len(t, relationships, 2)
+	assert.Equal(t, "mit", *relationships["license"].Data.ID)
+	// The relationships of the caller are left as is.
+	assert.Len(t, provider, 1)
+}
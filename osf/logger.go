@@ -0,0 +1,209 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Logger receives structured log records. Its methods take a message followed
+// by alternating keys and values, so *slog.Logger satisfies it as is. See
+// LoggerFuncs and LoggerFunc to adapt other loggers.
+//
+// When Client.Logger is set, every request is logged with its method, its URL
+// with secrets redacted, its status, duration and number of retries: at the
+// debug level if it succeeds, at the warn level if it fails with a client
+// error, along with its JSON:API errors, and at the error level otherwise.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, keysAndValues ...interface{}) {}
+func (nopLogger) Info(msg string, keysAndValues ...interface{})  {}
+func (nopLogger) Warn(msg string, keysAndValues ...interface{})  {}
+func (nopLogger) Error(msg string, keysAndValues ...interface{}) {}
+
+func (c *Client) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
+// LoggerFuncs adapts loggers whose leveled methods taking keys and values
+// have other names, such as *zap.SugaredLogger:
+//
+//	client.Logger = osf.LoggerFuncs{DebugFunc: sugar.Debugw, InfoFunc: sugar.Infow, WarnFunc: sugar.Warnw, ErrorFunc: sugar.Errorw}
+type LoggerFuncs struct {
+	DebugFunc func(msg string, keysAndValues ...interface{})
+	InfoFunc  func(msg string, keysAndValues ...interface{})
+	WarnFunc  func(msg string, keysAndValues ...interface{})
+	ErrorFunc func(msg string, keysAndValues ...interface{})
+}
+
+func (l LoggerFuncs) call(f func(string, ...interface{}), msg string, keysAndValues []interface{}) {
+	if f != nil {
+		f(msg, keysAndValues...)
+	}
+}
+
+func (l LoggerFuncs) Debug(msg string, keysAndValues ...interface{}) {
+	l.call(l.DebugFunc, msg, keysAndValues)
+}
+func (l LoggerFuncs) Info(msg string, keysAndValues ...interface{}) {
+	l.call(l.InfoFunc, msg, keysAndValues)
+}
+func (l LoggerFuncs) Warn(msg string, keysAndValues ...interface{}) {
+	l.call(l.WarnFunc, msg, keysAndValues)
+}
+func (l LoggerFuncs) Error(msg string, keysAndValues ...interface{}) {
+	l.call(l.ErrorFunc, msg, keysAndValues)
+}
+
+// LogLevel is the level of a log record.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// LoggerFunc adapts loggers taking fields as a map, such as logrus:
+//
+//	client.Logger = osf.LoggerFunc(func(level osf.LogLevel, msg string, fields map[string]interface{}) {
+//		logrus.WithFields(fields).Log(logrusLevels[level], msg)
+//	})
+type LoggerFunc func(level LogLevel, msg string, fields map[string]interface{})
+
+func (f LoggerFunc) log(level LogLevel, msg string, keysAndValues []interface{}) {
+	fields := make(map[string]interface{}, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	f(level, msg, fields)
+}
+
+func (f LoggerFunc) Debug(msg string, keysAndValues ...interface{}) {
+	f.log(LogLevelDebug, msg, keysAndValues)
+}
+func (f LoggerFunc) Info(msg string, keysAndValues ...interface{}) {
+	f.log(LogLevelInfo, msg, keysAndValues)
+}
+func (f LoggerFunc) Warn(msg string, keysAndValues ...interface{}) {
+	f.log(LogLevelWarn, msg, keysAndValues)
+}
+func (f LoggerFunc) Error(msg string, keysAndValues ...interface{}) {
+	f.log(LogLevelError, msg, keysAndValues)
+}
+
+// NewStdLogger returns a Logger writing the records of at least level to l,
+// as "LEVEL msg key=value ...".
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	return LoggerFunc(func(recordLevel LogLevel, msg string, fields map[string]interface{}) {
+		if recordLevel < level {
+			return
+		}
+		var b strings.Builder
+		b.WriteString(recordLevel.String())
+		b.WriteString(" ")
+		b.WriteString(msg)
+		for _, key := range sortedKeys(fields) {
+			fmt.Fprintf(&b, " %s=%v", key, fields[key])
+		}
+		l.Print(b.String())
+	})
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// redactedParams are the query parameters whose values are secrets.
+var redactedParams = []string{"view_only", "access_token", "token", "code", "client_secret", "refresh_token"}
+
+// RedactURL returns u with its password and secret query parameters replaced by "REDACTED".
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	redacted := *u
+	if redacted.RawQuery != "" {
+		q := redacted.Query()
+		changed := false
+		for _, param := range redactedParams {
+			if _, ok := q[param]; ok {
+				q.Set(param, "REDACTED")
+				changed = true
+			}
+		}
+		if changed {
+			redacted.RawQuery = q.Encode()
+		}
+	}
+	return redacted.Redacted()
+}
+
+// logRequest logs the outcome of a request at a level depending on it.
+func logRequest(ctx context.Context, logger Logger, req *http.Request, resp *http.Response, err error, duration time.Duration, attempts int, extra ...interface{}) {
+	kv := []interface{}{
+		"operation", RequestOperation(ctx),
+		"method", req.Method,
+		"url", RedactURL(req.URL),
+		"status", statusCode(resp),
+		"duration", duration,
+	}
+	if attempts > 1 {
+		kv = append(kv, "retries", attempts-1)
+	}
+
+	if errResp, ok := err.(*ErrorResponse); ok {
+		details := make([]string, 0, len(errResp.Errors))
+		for _, e := range errResp.Errors {
+			details = append(details, e.Error())
+		}
+		kv = append(kv, "errors", details)
+	} else if err != nil {
+		kv = append(kv, "error", err)
+	}
+	kv = append(kv, extra...)
+
+	switch {
+	case err == nil:
+		logger.Debug("OSF request", kv...)
+	case resp != nil && resp.StatusCode < http.StatusInternalServerError:
+		logger.Warn("OSF request failed", kv...)
+	default:
+		logger.Error("OSF request failed", kv...)
+	}
+}
@@ -0,0 +1,266 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Common values of Log.Action.
+const (
+	LogActionProjectCreated     = "project_created"
+	LogActionMadePublic         = "made_public"
+	LogActionMadePrivate        = "made_private"
+	LogActionEditedTitle        = "edit_title"
+	LogActionEditedDescription  = "edit_description"
+	LogActionContributorAdded   = "contributor_added"
+	LogActionContributorRemoved = "contributor_removed"
+	LogActionPermissionsUpdated = "permissions_updated"
+	LogActionTagAdded           = "tag_added"
+	LogActionTagRemoved         = "tag_removed"
+	LogActionFileAdded          = "osf_storage_file_added"
+	LogActionFileUpdated        = "osf_storage_file_updated"
+	LogActionFileRemoved        = "osf_storage_file_removed"
+	LogActionFolderCreated      = "osf_storage_folder_created"
+	LogActionAddonFileMoved     = "addon_file_moved"
+	LogActionAddonFileRenamed   = "addon_file_renamed"
+	LogActionWikiUpdated        = "wiki_updated"
+	LogActionWikiDeleted        = "wiki_deleted"
+)
+
+type LogsService service
+
+type LogLinks struct {
+	Self *string `json:"self"`
+}
+
+// Log is an entry of the activity log of a node. Params holds the parameters of
+// the action, decoded into *FileLogParams, *ContributorsLogParams,
+// *TagLogParams or *WikiLogParams depending on Action, and into *LogParams
+// for other actions.
+type Log struct {
+	ID string `json:"id"`
+
+	Date      *Time           `json:"date"`
+	Action    string          `json:"action"`
+	RawParams json.RawMessage `json:"params"`
+
+	Params interface{} `json:"-"`
+
+	// UserID is the ID of the user who performed the action, if known.
+	UserID string `json:"-"`
+	// NodeID is the ID of the node the action was performed on.
+	NodeID string `json:"-"`
+
+	Links *LogLinks `json:"-"`
+}
+
+type LogParamsNode struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// LogParams are the parameters common to every action.
+type LogParams struct {
+	ParamsNode    *LogParamsNode `json:"params_node"`
+	ParamsProject *LogParamsNode `json:"params_project"`
+}
+
+type FileLogParams struct {
+	LogParams
+
+	Path  string            `json:"path"`
+	Kind  string            `json:"kind"`
+	URLs  map[string]string `json:"urls"`
+	Addon string            `json:"addon"`
+}
+
+type LogContributor struct {
+	ID         string `json:"id"`
+	FullName   string `json:"full_name"`
+	GivenName  string `json:"given_name"`
+	FamilyName string `json:"family_name"`
+	Active     bool   `json:"active"`
+}
+
+type ContributorsLogParams struct {
+	LogParams
+
+	Contributors []*LogContributor `json:"contributors"`
+}
+
+type TagLogParams struct {
+	LogParams
+
+	Tag string `json:"tag"`
+}
+
+type WikiLogParams struct {
+	LogParams
+
+	Page   string `json:"page"`
+	PageID string `json:"page_id"`
+}
+
+// decodeLogParams decodes the params of a log according to its action.
+func decodeLogParams(action string, raw json.RawMessage) (interface{}, error) {
+	var params interface{}
+	switch action {
+	case LogActionFileAdded, LogActionFileUpdated, LogActionFileRemoved, LogActionFolderCreated,
+		LogActionAddonFileMoved, LogActionAddonFileRenamed:
+		params = &FileLogParams{}
+	case LogActionContributorAdded, LogActionContributorRemoved, LogActionPermissionsUpdated:
+		params = &ContributorsLogParams{}
+	case LogActionTagAdded, LogActionTagRemoved:
+		params = &TagLogParams{}
+	case LogActionWikiUpdated, LogActionWikiDeleted:
+		params = &WikiLogParams{}
+	default:
+		params = &LogParams{}
+	}
+
+	if len(raw) == 0 || string(raw) == "null" {
+		return params, nil
+	}
+	if err := json.Unmarshal(raw, params); err != nil {
+		return nil, fmt.Errorf("error unmarshaling params of %s log: %w", action, err)
+	}
+	return params, nil
+}
+
+type LogsListOptions struct {
+	ListOptions
+
+	// Actions only lists the logs of these actions.
+	Actions []string `url:"filter[action],comma,omitempty"`
+	// Since only lists the logs logged at or after this time.
+	Since *time.Time `url:"filter[date][gte],omitempty" layout:"2006-01-02T15:04:05.999999Z07:00"`
+	// Until only lists the logs logged at or before this time.
+	Until *time.Time `url:"filter[date][lte],omitempty" layout:"2006-01-02T15:04:05.999999Z07:00"`
+	// Sort orders the logs, e.g. "date" for the oldest first. Defaults to the newest first.
+	Sort string `url:"sort,omitempty"`
+}
+
+func transformLog(raw *Data[*Log, *LogLinks]) (*Log, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["user"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.UserID = *rel.Data.ID
+	}
+	if rel, ok := raw.Relationships["node"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.NodeID = *rel.Data.ID
+	}
+
+	params, err := decodeLogParams(obj.Action, obj.RawParams)
+	if err != nil {
+		return nil, err
+	}
+	obj.Params = params
+
+	return obj, nil
+}
+
+func (s *LogsService) ListNodeLogs(ctx context.Context, nodeID string, opts *LogsListOptions) ([]*Log, *ManyPayload[*Log, *LogLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("nodes/%s/logs/", nodeID), opts, transformLog)
+}
+
+func (s *LogsService) ListUserLogs(ctx context.Context, userID string, opts *LogsListOptions) ([]*Log, *ManyPayload[*Log, *LogLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("users/%s/logs/", userID), opts, transformLog)
+}
+
+func (s *LogsService) GetLogByID(ctx context.Context, id string) (*Log, *SinglePayload[*Log, *LogLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("logs/%s/", id), transformLog)
+}
+
+// LogIterator iterates over the logs of a node or a user as they are logged.
+type LogIterator struct {
+	s       *LogsService
+	u       string
+	actions []string
+
+	since time.Time
+	// seen holds the IDs of the logs already returned which were logged at since.
+	seen map[string]bool
+}
+
+// NewNodeLogIterator returns an iterator over the logs of a node logged at or
+// after since. Only the logs of actions are returned, if any.
+func (s *LogsService) NewNodeLogIterator(nodeID string, since time.Time, actions ...string) *LogIterator {
+	return &LogIterator{s: s, u: fmt.Sprintf("nodes/%s/logs/", nodeID), since: since, actions: actions, seen: map[string]bool{}}
+}
+
+// NewUserLogIterator returns an iterator over the logs of a user logged at or
+// after since. Only the logs of actions are returned, if any.
+func (s *LogsService) NewUserLogIterator(userID string, since time.Time, actions ...string) *LogIterator {
+	return &LogIterator{s: s, u: fmt.Sprintf("users/%s/logs/", userID), since: since, actions: actions, seen: map[string]bool{}}
+}
+
+// Since returns the date of the latest log returned, which can be persisted to
+// resume iterating later.
+func (it *LogIterator) Since() time.Time {
+	return it.since
+}
+
+// Next returns the logs logged since the previous call, oldest first. It
+// returns an empty slice if there are no new logs.
+func (it *LogIterator) Next(ctx context.Context) ([]*Log, error) {
+	since := it.since
+	opts := &LogsListOptions{
+		ListOptions: ListOptions{Page: 1, PerPage: 100},
+		Actions:     it.actions,
+		Since:       &since,
+		Sort:        "date",
+	}
+
+	var logs []*Log
+	for {
+		page, res, err := listResources(it.s.client, ctx, it.u, opts, transformLog)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, log := range page {
+			if log.Date == nil || it.seen[log.ID] {
+				continue
+			}
+			logs = append(logs, log)
+
+			if log.Date.After(it.since) {
+				it.since = log.Date.Time
+				it.seen = map[string]bool{}
+			}
+			it.seen[log.ID] = true
+		}
+
+		if len(page) == 0 || res.PaginationMeta == nil || opts.Page*opts.PerPage >= res.PaginationMeta.Total {
+			return logs, nil
+		}
+		opts.Page++
+	}
+}
+
+// Tail polls for new logs every interval and calls fn for each of them, oldest
+// first, until ctx is done or fn returns an error.
+func (it *LogIterator) Tail(ctx context.Context, interval time.Duration, fn func(log *Log) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		logs, err := it.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			if err := fn(log); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
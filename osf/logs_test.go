@@ -0,0 +1,65 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogsService_NodeLogIterator(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/nodes/abc12/logs/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "date", r.URL.Query().Get("sort"))
+		assert.Equal(t, "osf_storage_file_added,contributor_added", r.URL.Query().Get("filter[action]"))
+
+		calls++
+		switch calls {
+		case 1:
+			assert.Equal(t, "2022-05-01T00:00:00Z", r.URL.Query().Get("filter[date][gte]"))
+			fmt.Fprint(w, `{"data":[
+				{"id":"l1","type":"logs","attributes":{"date":"2022-05-02T10:00:00","action":"osf_storage_file_added",
+				 "params":{"path":"/data.csv","params_node":{"id":"abc12","title":"Study"}}},
+				 "relationships":{"user":{"data":{"id":"u1","type":"users"}}}},
+				{"id":"l2","type":"logs","attributes":{"date":"2022-05-03T10:00:00","action":"contributor_added",
+				 "params":{"contributors":[{"id":"u2","full_name":"Jane Doe"}]}}}
+			],"links":{"meta":{"total":2,"per_page":100}}}`)
+		case 2:
+			assert.Equal(t, "2022-05-03T10:00:00Z", r.URL.Query().Get("filter[date][gte]"))
+			fmt.Fprint(w, `{"data":[
+				{"id":"l2","type":"logs","attributes":{"date":"2022-05-03T10:00:00","action":"contributor_added","params":{}}}
+			],"links":{"meta":{"total":1,"per_page":100}}}`)
+		}
+	})
+
+	ctx := context.Background()
+	it := client.Logs.NewNodeLogIterator("abc12", time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC), LogActionFileAdded, LogActionContributorAdded)
+
+	logs, err := it.Next(ctx)
+	if err != nil {
+		t.Fatalf("LogIterator.Next returned error: %v", err)
+	}
+	assert.Len(t, logs, 2)
+	assert.Equal(t, "u1", logs[0].UserID)
+	if params, ok := logs[0].Params.(*FileLogParams); assert.True(t, ok) {
+		assert.Equal(t, "/data.csv", params.Path)
+		assert.Equal(t, "Study", params.ParamsNode.Title)
+	}
+	if params, ok := logs[1].Params.(*ContributorsLogParams); assert.True(t, ok) {
+		assert.Equal(t, "Jane Doe", params.Contributors[0].FullName)
+	}
+
+	logs, err = it.Next(ctx)
+	if err != nil {
+		t.Fatalf("LogIterator.Next returned error: %v", err)
+	}
+	assert.Empty(t, logs)
+	assert.Equal(t, time.Date(2022, time.May, 3, 10, 0, 0, 0, time.UTC), it.Since())
+}
@@ -0,0 +1,72 @@
+package osf
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestMetrics is a Middleware counting the requests of a client, by status,
+// along with their durations, e.g. to export them periodically. The zero value
+// is ready to use.
+type RequestMetrics struct {
+	mu       sync.Mutex
+	requests int64
+	failures int64
+	statuses map[int]int64
+	duration time.Duration
+}
+
+// RequestMetricsSnapshot holds the values of RequestMetrics at some point.
+type RequestMetricsSnapshot struct {
+	Requests int64
+	// Failures are the requests which failed, with an error status or no response at all.
+	Failures int64
+	// Statuses counts the responses by status code.
+	Statuses      map[int]int64
+	TotalDuration time.Duration
+}
+
+func NewRequestMetrics() *RequestMetrics {
+	return &RequestMetrics{statuses: map[int]int64{}}
+}
+
+func (m *RequestMetrics) Wrap(next RequestHandler) RequestHandler {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next(ctx, req)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.requests++
+		if err != nil {
+			m.failures++
+		}
+		if resp != nil {
+			if m.statuses == nil {
+				m.statuses = map[int]int64{}
+			}
+			m.statuses[resp.StatusCode]++
+		}
+		m.duration += time.Since(start)
+
+		return resp, err
+	}
+}
+
+func (m *RequestMetrics) Snapshot() RequestMetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make(map[int]int64, len(m.statuses))
+	for status, n := range m.statuses {
+		statuses[status] = n
+	}
+	return RequestMetricsSnapshot{
+		Requests:      m.requests,
+		Failures:      m.failures,
+		Statuses:      statuses,
+		TotalDuration: m.duration,
+	}
+}
@@ -0,0 +1,192 @@
+package osf
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// RequestHandler performs a request, to the API or to WaterButler. Requests
+// which fail with an error status return an *ErrorResponse along with the response.
+type RequestHandler func(ctx context.Context, req *http.Request) (*http.Response, error)
+
+// Middleware wraps the execution of every request of a client, e.g. to
+// modify requests or observe their outcome. Retries happen within next.
+type Middleware interface {
+	Wrap(next RequestHandler) RequestHandler
+}
+
+// MiddlewareFunc is an adapter to allow the use of ordinary functions as a Middleware.
+type MiddlewareFunc func(next RequestHandler) RequestHandler
+
+func (f MiddlewareFunc) Wrap(next RequestHandler) RequestHandler {
+	return f(next)
+}
+
+// WithMiddleware adds middlewares to the client, the first one being the outermost.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(o *clientOptions) error {
+		o.requestMiddlewares = append(o.requestMiddlewares, middlewares...)
+		return nil
+	}
+}
+
+// execute performs req through the middlewares of the client. Every request of
+// the client goes through it.
+func (c *Client) execute(ctx context.Context, req *http.Request) (*http.Response, error) {
+	ctx, _ = withRequestInfo(ctx)
+
+	handler := c.cachingHandler(c.send)
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		handler = c.Middlewares[i].Wrap(handler)
+	}
+	if c.Logger != nil {
+		handler = loggingHandler(c.Logger, false, handler)
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Hooks is a Middleware calling Before and After around every request.
+type Hooks struct {
+	// Before is called before a request is sent. It may modify the request, or
+	// abort it by returning an error.
+	Before func(ctx context.Context, req *http.Request) error
+	// After is called with the outcome of a request. err is an *ErrorResponse
+	// if the request failed with an error status. The error After returns, if
+	// any, replaces err.
+	After func(ctx context.Context, req *http.Request, resp *http.Response, err error) error
+}
+
+func (h *Hooks) Wrap(next RequestHandler) RequestHandler {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		if h.Before != nil {
+			if err := h.Before(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := next(ctx, req)
+		if h.After != nil {
+			err = h.After(ctx, req, resp, err)
+		}
+		return resp, err
+	}
+}
+
+// HeaderMiddleware sets header on every request, e.g. tracing headers.
+func HeaderMiddleware(header http.Header) Middleware {
+	return &Hooks{Before: func(ctx context.Context, req *http.Request) error {
+		for key, values := range header {
+			req.Header[key] = append([]string(nil), values...)
+		}
+		return nil
+	}}
+}
+
+// QueryMiddleware adds query parameters to every request, e.g. "view_only".
+func QueryMiddleware(params url.Values) Middleware {
+	return &Hooks{Before: func(ctx context.Context, req *http.Request) error {
+		q := req.URL.Query()
+		for key, values := range params {
+			q[key] = append([]string(nil), values...)
+		}
+		req.URL.RawQuery = q.Encode()
+		return nil
+	}}
+}
+
+// maxLoggedBody is the size beyond which logged bodies are truncated.
+const maxLoggedBody = 64 << 10
+
+// LoggingMiddleware logs every request to logger like Client.Logger does,
+// along with its request and response bodies if logBodies is set.
+func LoggingMiddleware(logger Logger, logBodies bool) Middleware {
+	return MiddlewareFunc(func(next RequestHandler) RequestHandler {
+		return loggingHandler(logger, logBodies, next)
+	})
+}
+
+func loggingHandler(logger Logger, logBodies bool, next RequestHandler) RequestHandler {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		var reqBody string
+		if logBodies && req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				reqBody = readLoggedBody(body)
+			}
+		}
+
+		resp, err := next(ctx, req)
+
+		var extra []interface{}
+		if logBodies {
+			if reqBody != "" {
+				extra = append(extra, "request_body", reqBody)
+			}
+			if resp != nil && isTextual(resp.Header.Get("Content-Type")) {
+				b, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(b))
+				extra = append(extra, "response_body", truncateBody(b))
+			}
+		}
+
+		attempts := 1
+		if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok && info.attempts > 0 {
+			attempts = info.attempts
+		}
+		logRequest(ctx, logger, req, resp, err, time.Since(start), attempts, extra...)
+
+		return resp, err
+	}
+}
+
+func readLoggedBody(body io.ReadCloser) string {
+	defer body.Close()
+	b, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+	return truncateBody(b)
+}
+
+// redactedBodyKeys are the JSON keys whose values are secrets, like redactedParams.
+var redactedBodyKeys = []string{"client_secret", "token_id", "access_token", "refresh_token", "token"}
+
+// redactedBodyValues matches the string values of redactedBodyKeys, including
+// one cut short by truncation.
+var redactedBodyValues = func() *regexp.Regexp {
+	keys := make([]string, len(redactedBodyKeys))
+	for i, key := range redactedBodyKeys {
+		keys[i] = regexp.QuoteMeta(key)
+	}
+	return regexp.MustCompile(`"(` + strings.Join(keys, "|") + `)"(\s*:\s*)"(?:[^"\\]|\\.)*"?`)
+}()
+
+// truncateBody returns the body b to log, with the values of redactedBodyKeys
+// replaced by "REDACTED".
+func truncateBody(b []byte) string {
+	truncated := len(b) > maxLoggedBody
+	if truncated {
+		b = b[:maxLoggedBody]
+	}
+	body := redactedBodyValues.ReplaceAllString(string(b), `"$1"$2"REDACTED"`)
+	if truncated {
+		body += "..."
+	}
+	return body
+}
+
+// isTextual reports whether a body of contentType is worth logging, unlike file contents.
+func isTextual(contentType string) bool {
+	return strings.Contains(contentType, "json") || strings.HasPrefix(contentType, "text/")
+}
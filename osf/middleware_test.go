@@ -0,0 +1,175 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger is a Logger keeping its records, for tests.
+type recordingLogger struct {
+	mu      sync.Mutex
+	records []logRecord
+}
+
+type logRecord struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+func (l *recordingLogger) log(level string, msg string, keysAndValues []interface{}) {
+	attrs := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		attrs[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, logRecord{level: level, msg: msg, attrs: attrs})
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log("debug", msg, keysAndValues)
+}
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log("info", msg, keysAndValues)
+}
+func (l *recordingLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log("warn", msg, keysAndValues)
+}
+func (l *recordingLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log("error", msg, keysAndValues)
+}
+
+func TestMiddleware_Hooks(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/abc12/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "trace-1", r.Header.Get("X-Trace-Id"))
+		assert.Equal(t, "k3y", r.URL.Query().Get("view_only"))
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"errors":[{"detail":"You do not have permission to perform this action."}]}`)
+	})
+
+	var errResp *ErrorResponse
+	var operation string
+	metrics := NewRequestMetrics()
+	client.Middlewares = []Middleware{
+		metrics,
+		&Hooks{
+			Before: func(ctx context.Context, req *http.Request) error {
+				operation = RequestOperation(ctx)
+				return nil
+			},
+			After: func(ctx context.Context, req *http.Request, resp *http.Response, err error) error {
+				errResp, _ = err.(*ErrorResponse)
+				return err
+			},
+		},
+		HeaderMiddleware(http.Header{"X-Trace-Id": {"trace-1"}}),
+		QueryMiddleware(url.Values{"view_only": {"k3y"}}),
+	}
+
+	_, _, err := client.Nodes.GetNodeByID(context.Background(), "abc12")
+	assert.EqualError(t, err, "You do not have permission to perform this action.")
+	assert.Equal(t, "Nodes.GetNodeByID", operation)
+
+	if assert.NotNil(t, errResp) {
+		assert.Equal(t, http.StatusForbidden, errResp.Response.StatusCode)
+		assert.Equal(t, "You do not have permission to perform this action.", errResp.Errors[0].Detail)
+	}
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, int64(1), snapshot.Requests)
+	assert.Equal(t, int64(1), snapshot.Failures)
+	assert.Equal(t, map[int]int64{http.StatusForbidden: 1}, snapshot.Statuses)
+}
+
+func TestRequestMetrics_zeroValue(t *testing.T) {
+	var metrics RequestMetrics
+	assert.Empty(t, metrics.Snapshot().Statuses)
+
+	handler := metrics.Wrap(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	req, _ := http.NewRequest(http.MethodGet, "https://api.osf.io/v2/", nil)
+	_, err := handler(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int64{http.StatusOK: 1}, metrics.Snapshot().Statuses)
+}
+
+func TestMiddleware_Abort(t *testing.T) {
+	client, _, teardown := setupServer()
+	defer teardown()
+
+	client.Middlewares = []Middleware{&Hooks{Before: func(ctx context.Context, req *http.Request) error {
+		return fmt.Errorf("read-only mode")
+	}}}
+
+	err := client.Tokens.DeleteToken(context.Background(), "tok1")
+	assert.ErrorContains(t, err, "read-only mode")
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"tok1","type":"tokens","attributes":{"name":"ci","token_id":"s3cr3t"}}}`)
+	})
+
+	logger := &recordingLogger{}
+	client.Middlewares = []Middleware{LoggingMiddleware(logger, true)}
+
+	token, _, err := client.Tokens.CreateToken(context.Background(), &TokenRequest{Name: StringPointer("ci")})
+	if err != nil {
+		t.Fatalf("Tokens.CreateToken returned error: %v", err)
+	}
+	assert.Equal(t, "tok1", token.ID)
+
+	if assert.Len(t, logger.records, 1) {
+		record := logger.records[0]
+		assert.Equal(t, "debug", record.level)
+		assert.Equal(t, http.MethodPost, record.attrs["method"])
+		assert.Equal(t, http.StatusCreated, record.attrs["status"])
+		assert.Contains(t, record.attrs["request_body"], `"name":"ci"`)
+		assert.Contains(t, record.attrs["response_body"], `"id":"tok1"`)
+		assert.Contains(t, record.attrs["response_body"], `"token_id":"REDACTED"`)
+		assert.NotContains(t, record.attrs["response_body"], "s3cr3t")
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	assert.Equal(t, `{"client_secret": "REDACTED","name":"app"}`, truncateBody([]byte(`{"client_secret": "s\"3cr3t","name":"app"}`)))
+	assert.Equal(t, `{"access_token":"REDACTED","token":"REDACTED"}`, truncateBody([]byte(`{"access_token":"a","token":"b"}`)))
+
+	// The secret is cut short by truncation.
+	long := `{"name":"` + strings.Repeat("x", maxLoggedBody-23) + `","token":"s3cr3tvalue"}`
+	body := truncateBody([]byte(long))
+	assert.NotContains(t, body, "s3c")
+	assert.True(t, strings.HasSuffix(body, `"token":"REDACTED"...`))
+}
+
+func TestParseOperationName(t *testing.T) {
+	tests := map[string]string{
+		packagePath + "(*PreprintsService).ListPreprints":          "Preprints.ListPreprints",
+		packagePath + "(*CommentsService).GetCommentThreads.func1": "Comments.GetCommentThreads",
+		packagePath + "(*LogIterator).Next":                        "LogIterator.Next",
+		packagePath + "(*Client).execute":                          "",
+		packagePath + "(*PreprintsService).transform":              "",
+		packagePath + "listResources[...]":                         "",
+		"main.main":                                                "",
+	}
+	for function, want := range tests {
+		assert.Equal(t, want, parseOperationName(function), function)
+	}
+}
@@ -0,0 +1,129 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Values of Node.Category.
+const (
+	NodeCategoryProject     = "project"
+	NodeCategoryAnalysis    = "analysis"
+	NodeCategoryData        = "data"
+	NodeCategoryHypothesis  = "hypothesis"
+	NodeCategoryMethods     = "methods and measures"
+	NodeCategoryProcedure   = "procedure"
+	NodeCategoryCommunicate = "communication"
+	NodeCategoryOther       = "other"
+)
+
+type NodesService service
+
+type NodeLicense struct {
+	CopyrightHolders []string `json:"copyright_holders"`
+	Year             string   `json:"year"`
+}
+
+type NodeLinks struct {
+	Self *string `json:"self"`
+	Html *string `json:"html"`
+}
+
+// Node is an OSF project or component.
+type Node struct {
+	ID string `json:"id"`
+
+	Title                  string       `json:"title"`
+	Description            string       `json:"description"`
+	Category               string       `json:"category"`
+	CustomCitation         *string      `json:"custom_citation"`
+	DateCreated            *Time        `json:"date_created"`
+	DateModified           *Time        `json:"date_modified"`
+	Registration           bool         `json:"registration"`
+	Preprint               bool         `json:"preprint"`
+	Fork                   bool         `json:"fork"`
+	Collection             bool         `json:"collection"`
+	Tags                   []string     `json:"tags"`
+	AccessRequestsEnabled  bool         `json:"access_requests_enabled"`
+	NodeLicense            *NodeLicense `json:"node_license"`
+	CurrentUserCanComment  bool         `json:"current_user_can_comment"`
+	CurrentUserPermissions []string     `json:"current_user_permissions"`
+	Public                 bool         `json:"public"`
+	WikiEnabled            bool         `json:"wiki_enabled"`
+
+	Links *NodeLinks `json:"-"`
+}
+
+// NodeRequest is the input of node creations and updates. Nil fields are left
+// unchanged by updates.
+type NodeRequest struct {
+	Title       *string   `json:"title,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Category    *string   `json:"category,omitempty"`
+	Public      *bool     `json:"public,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
+}
+
+// NodeUpdate is the update of one of the nodes of BulkUpdateNodes.
+type NodeUpdate struct {
+	NodeID string
+	Input  *NodeRequest
+}
+
+func transformNode(raw *Data[*Node, *NodeLinks]) (*Node, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func (s *NodesService) GetNodeByID(ctx context.Context, id string) (*Node, *SinglePayload[*Node, *NodeLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("nodes/%s/", id), transformNode)
+}
+
+// BulkCreateNodes creates up to 100 nodes in one request. Title and Category
+// are required. The results are in the order of inputs.
+func (s *NodesService) BulkCreateNodes(ctx context.Context, inputs []*NodeRequest) ([]*BulkResult[*Node], error) {
+	body := &BulkPayload[*NodeRequest]{Data: make([]*Data[*NodeRequest, interface{}], 0, len(inputs))}
+	for _, input := range inputs {
+		body.Data = append(body.Data, &Data[*NodeRequest, interface{}]{Type: TypeNodes, Attributes: input})
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "nodes/", body)
+	if err != nil {
+		return nil, err
+	}
+
+	return doBulk(s.client, ctx, req, make([]string, len(inputs)), transformNode)
+}
+
+// BulkUpdateNodes updates up to 100 nodes in one request, e.g. to set the tags
+// of many nodes. Either every update is applied or none is; the results tell
+// which updates failed. The results are in the order of updates.
+func (s *NodesService) BulkUpdateNodes(ctx context.Context, updates []*NodeUpdate) ([]*BulkResult[*Node], error) {
+	ids := make([]string, 0, len(updates))
+	body := &BulkPayload[*NodeRequest]{Data: make([]*Data[*NodeRequest, interface{}], 0, len(updates))}
+	for _, update := range updates {
+		id := update.NodeID
+		ids = append(ids, id)
+		body.Data = append(body.Data, &Data[*NodeRequest, interface{}]{Type: TypeNodes, ID: &id, Attributes: update.Input})
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, "nodes/", body)
+	if err != nil {
+		return nil, err
+	}
+
+	return doBulk(s.client, ctx, req, ids, transformNode)
+}
+
+// BulkDeleteNodes deletes up to 100 nodes in one request. The values of the
+// results are nil.
+func (s *NodesService) BulkDeleteNodes(ctx context.Context, ids ...string) ([]*BulkResult[*Node], error) {
+	req, err := s.client.NewRequest(http.MethodDelete, "nodes/", &BulkPayload[interface{}]{Data: newRelationshipsPayload(TypeNodes, ids...).Data})
+	if err != nil {
+		return nil, err
+	}
+
+	return doBulkNoContent[*Node](s.client, ctx, req, ids)
+}
@@ -0,0 +1,95 @@
+package osf
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+const packagePath = "github.com/joshuabezaleel/go-osf/osf."
+
+type requestInfoKey struct{}
+
+// requestInfo describes the execution of a request to its middlewares.
+type requestInfo struct {
+	operation string
+	attempts  int
+	cache     CacheStatus
+}
+
+// withRequestInfo returns a context carrying a new requestInfo, unless ctx already carries one.
+func withRequestInfo(ctx context.Context) (context.Context, *requestInfo) {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		return ctx, info
+	}
+	info := &requestInfo{operation: operationName()}
+	return context.WithValue(ctx, requestInfoKey{}, info), info
+}
+
+// RequestOperation returns the name of the method making the request of ctx,
+// such as "Preprints.ListPreprints", or an empty string if it is unknown. It
+// is meant for middlewares, e.g. to name spans.
+func RequestOperation(ctx context.Context) string {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		return info.operation
+	}
+	return ""
+}
+
+// RequestAttempts returns the number of times the request of ctx has been
+// sent so far, retries included. It is meant for middlewares.
+func RequestAttempts(ctx context.Context) int {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		return info.attempts
+	}
+	return 0
+}
+
+// operationName returns the name of the innermost exported method of this
+// package in the call stack, the "Service" suffix of its receiver trimmed.
+func operationName() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if name := parseOperationName(frame.Function); name != "" {
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// parseOperationName turns the function name of an exported method of this package, such as
+// "github.com/joshuabezaleel/go-osf/osf.(*PreprintsService).ListPreprints", into "Preprints.ListPreprints".
+func parseOperationName(function string) string {
+	if !strings.HasPrefix(function, packagePath+"(*") {
+		return ""
+	}
+	rest := strings.TrimPrefix(function, packagePath+"(*")
+
+	i := strings.Index(rest, ").")
+	if i < 0 {
+		return ""
+	}
+	receiver, method := rest[:i], rest[i+2:]
+	// Closures within the method are named like "ListPreprints.func1".
+	if j := strings.Index(method, "."); j >= 0 {
+		method = method[:j]
+	}
+	if !isExported(receiver) || !isExported(method) || receiver == "Client" {
+		return ""
+	}
+
+	return strings.TrimSuffix(receiver, "Service") + "." + method
+}
+
+func isExported(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package osf
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+// TransportMiddleware wraps the transport of the HTTP client, e.g. to add
+// headers to every request.
+type TransportMiddleware func(next http.RoundTripper) http.RoundTripper
+
+type clientOptions struct {
+	httpClient  *http.Client
+	tokenSource oauth2.TokenSource
+	timeout     time.Duration
+	middlewares []TransportMiddleware
+
+	baseURL            string
+	userAgentSuffix    string
+	retryPolicy        *RetryPolicy
+	rateLimiter        *rate.Limiter
+	logger             Logger
+	requestMiddlewares []Middleware
+	cache              Cache
+	cacheTTL           time.Duration
+	cacheScope         string
+
+	// token is the token of WithToken, which identifies the client in the keys of the cache.
+	token string
+}
+
+// Option configures a Client created by NewClientWithOptions.
+type Option func(o *clientOptions) error
+
+// WithHTTPClient sets the HTTP client the other options build upon. It is not modified.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) error {
+		o.httpClient = httpClient
+		return nil
+	}
+}
+
+// WithToken authenticates requests with a personal access token or an OAuth2 access token.
+func WithToken(token string) Option {
+	return func(o *clientOptions) error {
+		if token == "" {
+			return fmt.Errorf("empty token")
+		}
+		o.tokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+		o.token = token
+		return nil
+	}
+}
+
+// WithTokenSource authenticates requests with the tokens of ts, e.g. to refresh OAuth2 tokens.
+func WithTokenSource(ts oauth2.TokenSource) Option {
+	return func(o *clientOptions) error {
+		o.tokenSource = ts
+		o.token = ""
+		return nil
+	}
+}
+
+// WithEnvironment sends requests to the API of env.
+func WithEnvironment(env *Environment) Option {
+	return func(o *clientOptions) error {
+		if env == nil {
+			return fmt.Errorf("nil environment")
+		}
+		o.baseURL = env.BaseURL
+		return nil
+	}
+}
+
+// WithBaseURL sends requests to the API at baseURL, which must end with a slash.
+func WithBaseURL(baseURL string) Option {
+	return func(o *clientOptions) error {
+		o.baseURL = baseURL
+		return nil
+	}
+}
+
+// WithUserAgent appends suffix to the User-Agent header, e.g. "my-harvester/1.2".
+func WithUserAgent(suffix string) Option {
+	return func(o *clientOptions) error {
+		o.userAgentSuffix = suffix
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request, including reading the response body.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if timeout < 0 {
+			return fmt.Errorf("negative timeout %s", timeout)
+		}
+		o.timeout = timeout
+		return nil
+	}
+}
+
+// WithRetryPolicy retries failed requests according to policy, e.g. DefaultRetryPolicy.
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		if policy != nil && (policy.MaxRetries < 0 || policy.MinBackoff < 0 || policy.MaxBackoff < 0) {
+			return fmt.Errorf("invalid retry policy: negative values")
+		}
+		o.retryPolicy = policy
+		return nil
+	}
+}
+
+// WithRateLimit limits requests to perSecond on average, in bursts of up to burst requests.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(o *clientOptions) error {
+		if perSecond <= 0 || burst <= 0 {
+			return fmt.Errorf("invalid rate limit: %v per second in bursts of %d", perSecond, burst)
+		}
+		o.rateLimiter = rate.NewLimiter(rate.Limit(perSecond), burst)
+		return nil
+	}
+}
+
+// WithLogger logs the activity of the client to logger.
+func WithLogger(logger Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = logger
+		return nil
+	}
+}
+
+// WithTransportMiddleware wraps the transport of the HTTP client with
+// middlewares, the first one being the outermost.
+func WithTransportMiddleware(middlewares ...TransportMiddleware) Option {
+	return func(o *clientOptions) error {
+		o.middlewares = append(o.middlewares, middlewares...)
+		return nil
+	}
+}
+
+// validateBaseURL checks that relative paths can be appended to baseURL.
+func validateBaseURL(baseURL *url.URL) error {
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		return fmt.Errorf("BaseURL must have a trailing slash, but %q does not", baseURL)
+	}
+	return nil
+}
+
+// NewClientWithOptions returns a client configured with opts, which is
+// validated up front. Without options, it is equivalent to NewClient(nil).
+func NewClientWithOptions(opts ...Option) (*Client, error) {
+	o := &clientOptions{}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	httpClient := &http.Client{}
+	if o.httpClient != nil {
+		clientCopy := *o.httpClient
+		httpClient = &clientCopy
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if o.tokenSource != nil {
+		transport = &oauth2.Transport{Source: oauth2.ReuseTokenSource(nil, o.tokenSource), Base: transport}
+	}
+	for i := len(o.middlewares) - 1; i >= 0; i-- {
+		transport = o.middlewares[i](transport)
+	}
+	httpClient.Transport = transport
+
+	c := NewClient(httpClient)
+
+	if o.baseURL != "" {
+		baseURL, err := url.Parse(o.baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BaseURL: %w", err)
+		}
+		if baseURL.Scheme == "" || baseURL.Host == "" {
+			return nil, fmt.Errorf("BaseURL must be absolute, but %q is not", o.baseURL)
+		}
+		c.BaseURL = baseURL
+	}
+	if err := validateBaseURL(c.BaseURL); err != nil {
+		return nil, err
+	}
+
+	if o.userAgentSuffix != "" {
+		c.UserAgent = c.UserAgent + " " + o.userAgentSuffix
+	}
+	c.RetryPolicy = o.retryPolicy
+	c.RateLimiter = o.rateLimiter
+	c.Logger = o.logger
+	c.Middlewares = o.requestMiddlewares
+	c.Cache = o.cache
+	c.CacheTTL = o.cacheTTL
+	c.CacheScope = o.cacheScope
+	if c.CacheScope == "" && o.tokenSource != nil {
+		// The token is added by the transport, which the cache does not see.
+		scope, err := tokenCacheScope(o.token)
+		if err != nil {
+			return nil, err
+		}
+		c.CacheScope = scope
+	}
+
+	return c, nil
+}
@@ -0,0 +1,84 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+	var order []string
+	middleware := func(name string) TransportMiddleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/licenses/l1/", r.URL.Path)
+		assert.Equal(t, "Bearer s3cr3t", r.Header.Get("Authorization"))
+		assert.Equal(t, "go-osf harvester/1.0", r.Header.Get("User-Agent"))
+		fmt.Fprint(w, `{"data":{"id":"l1","type":"licenses","attributes":{"name":"CC-By"}}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithOptions(
+		WithBaseURL(server.URL+"/v2/"),
+		WithToken("s3cr3t"),
+		WithUserAgent("harvester/1.0"),
+		WithTimeout(5*time.Second),
+		WithRateLimit(100, 10),
+		WithTransportMiddleware(middleware("outer"), middleware("inner")),
+	)
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+
+	license, _, err := client.Licenses.GetLicenseByID(context.Background(), "l1")
+	if err != nil {
+		t.Fatalf("Licenses.GetLicenseByID returned error: %v", err)
+	}
+	assert.Equal(t, "CC-By", license.Name)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, 5*time.Second, client.Client().Timeout)
+}
+
+func TestNewClientWithOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{"no trailing slash", WithBaseURL("https://api.osf.io/v2")},
+		{"relative base URL", WithBaseURL("/v2/")},
+		{"empty token", WithToken("")},
+		{"nil environment", WithEnvironment(nil)},
+		{"rate limit", WithRateLimit(0, 1)},
+		{"retry policy", WithRetryPolicy(&RetryPolicy{MaxRetries: -1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClientWithOptions(tt.opt)
+			assert.Error(t, err)
+		})
+	}
+
+	client, err := NewClientWithOptions(WithEnvironment(EnvironmentTest))
+	if err != nil {
+		t.Fatalf("NewClientWithOptions returned error: %v", err)
+	}
+	assert.Equal(t, "https://api.test.osf.io/v2/", client.BaseURL.String())
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
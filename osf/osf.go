@@ -12,9 +12,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -27,6 +29,29 @@ const (
 	TypeProviders         = "providers"
 	TypePreprintProviders = "preprint_providers"
 	TypeFiles             = "files"
+	TypeSubjects          = "subjects"
+	TypeTaxonomies        = "taxonomies"
+	TypeLicenses          = "licenses"
+	TypeUsers             = "users"
+	TypeContributors      = "contributors"
+	TypeInstitutions      = "institutions"
+	TypeNodes             = "nodes"
+	TypeRegistrations     = "registrations"
+	TypeGuids             = "guids"
+	TypeCollections       = "collections"
+	TypeCollectedMetadata = "collected-metadata"
+	TypeWikis             = "wikis"
+	TypeWikiVersions      = "wiki-versions"
+	TypeComments          = "comments"
+	TypeCommentReports    = "comment_reports"
+	TypeViewOnlyLinks     = "view_only_links"
+	TypeIdentifiers       = "identifiers"
+	TypeTokens            = "tokens"
+	TypeApplications      = "applications"
+	TypeScopes            = "scopes"
+
+	TypeCollectionSubmissions       = "collection-submission"
+	TypeCollectionSubmissionActions = "collection-submission-actions"
 )
 
 type Client struct {
@@ -37,13 +62,64 @@ type Client struct {
 
 	UserAgent string
 
+	// ProgressReporter, if set, receives progress reports for every file upload
+	// and download. It can be overridden per call with WithProgressReporter.
+	ProgressReporter ProgressReporter
+
+	// ViewOnlyKey, if set, is the key of a view-only link sent with every
+	// request, which grants read access to the private nodes of the link
+	// without an access token.
+	ViewOnlyKey string
+
+	// RetryPolicy, if set, retries failed requests.
+	RetryPolicy *RetryPolicy
+	// RateLimiter, if set, limits the rate of requests.
+	RateLimiter *rate.Limiter
+	// Logger, if set, logs the activity of the client.
+	Logger Logger
+	// Middlewares wrap the execution of every request, the first one being the outermost.
+	Middlewares []Middleware
+
+	// Cache, if set, caches the responses of GET requests. See WithCache.
+	Cache Cache
+	// CacheTTL is how long cached responses without validators are served without contacting OSF.
+	CacheTTL time.Duration
+	// CacheScope identifies the credentials of the client in the keys of Cache.
+	// Credentials added below the client, such as the token of
+	// NewClientWithOptions, are not seen by the cache, so clients sharing a
+	// cache must have distinct scopes unless they act as the same user. If it
+	// is empty, a random scope is used, so that entries are not shared.
+	CacheScope string
+
 	rateMu sync.Mutex
 
+	cacheMu          sync.Mutex
+	randomCacheScope string
+	// cacheModified holds when the client last modified each resource type, see WithCache.
+	cacheModified map[string]time.Time
+
 	common service
 
 	Preprints         *PreprintsService
 	PreprintProviders *PreprintProvidersService
 	Files             *FilesService
+	Nodes             *NodesService
+	Registrations     *RegistrationsService
+	Users             *UsersService
+	Subjects          *SubjectsService
+	Licenses          *LicensesService
+	Institutions      *InstitutionsService
+	Collections       *CollectionsService
+	Wikis             *WikisService
+	Comments          *CommentsService
+	Logs              *LogsService
+	ViewOnlyLinks     *ViewOnlyLinksService
+	Identifiers       *IdentifiersService
+	Guids             *GuidsService
+	Search            *SearchService
+	Tokens            *TokensService
+	Applications      *ApplicationsService
+	Scopes            *ScopesService
 }
 
 type service struct {
@@ -68,12 +144,30 @@ func NewClient(httpClient *http.Client) *Client {
 	c.Preprints = (*PreprintsService)(&c.common)
 	c.PreprintProviders = (*PreprintProvidersService)(&c.common)
 	c.Files = (*FilesService)(&c.common)
+	c.Nodes = (*NodesService)(&c.common)
+	c.Registrations = (*RegistrationsService)(&c.common)
+	c.Users = (*UsersService)(&c.common)
+	c.Subjects = (*SubjectsService)(&c.common)
+	c.Licenses = (*LicensesService)(&c.common)
+	c.Institutions = (*InstitutionsService)(&c.common)
+	c.Collections = (*CollectionsService)(&c.common)
+	c.Wikis = (*WikisService)(&c.common)
+	c.Comments = (*CommentsService)(&c.common)
+	c.Logs = (*LogsService)(&c.common)
+	c.ViewOnlyLinks = (*ViewOnlyLinksService)(&c.common)
+	c.Identifiers = (*IdentifiersService)(&c.common)
+	c.Guids = (*GuidsService)(&c.common)
+	c.Search = (*SearchService)(&c.common)
+	c.Tokens = (*TokensService)(&c.common)
+	c.Applications = (*ApplicationsService)(&c.common)
+	c.Scopes = (*ScopesService)(&c.common)
 	return c
 }
 
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
-	if !strings.HasSuffix(c.BaseURL.Path, "/") {
-		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	// BaseURL is validated by NewClientWithOptions, but may have been changed since.
+	if err := validateBaseURL(c.BaseURL); err != nil {
+		return nil, err
 	}
 
 	// u, err := c.BaseURL.Parse(urlStr)
@@ -81,6 +175,9 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	// 	return nil, err
 	// }
 	u := c.BaseURL.String() + urlStr
+	if c.ViewOnlyKey != "" {
+		u = appendQuery(u, "view_only", c.ViewOnlyKey)
+	}
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -99,7 +196,9 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		return nil, err
 	}
 
-	if body != nil {
+	if _, ok := body.(bulkPayload); ok {
+		req.Header.Set("Content-Type", mediaTypeBulk)
+	} else if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "*/*")
@@ -131,6 +230,52 @@ type Relationship struct {
 
 type Relationships map[string]Relationship
 
+// RelationshipsPayload is the payload of relationship endpoints, such as
+// /nodes/{id}/relationships/institutions/, which link a resource to others.
+type RelationshipsPayload struct {
+	Data []*Data[interface{}, interface{}] `json:"data"`
+}
+
+// newRelationshipsPayload builds a RelationshipsPayload linking to the resources of type typ with ids.
+func newRelationshipsPayload(typ string, ids ...string) *RelationshipsPayload {
+	payload := &RelationshipsPayload{Data: make([]*Data[interface{}, interface{}], 0, len(ids))}
+	for i := range ids {
+		payload.Data = append(payload.Data, &Data[interface{}, interface{}]{Type: typ, ID: &ids[i]})
+	}
+	return payload
+}
+
+// mediaTypeBulk is the media type of the JSON:API bulk extension, which OSF
+// requires to create, update or delete several resources in one request.
+const mediaTypeBulk = "application/vnd.api+json; ext=bulk"
+
+// bulkPayload is implemented by the bodies NewRequest sends as bulk requests.
+type bulkPayload interface {
+	bulk()
+}
+
+// BulkPayload is the payload of bulk requests, whose data is an array of
+// resources instead of a single one. NewRequest sends it with the media type of
+// the bulk extension.
+type BulkPayload[T any] struct {
+	Data []*Data[T, interface{}] `json:"data"`
+}
+
+func (*BulkPayload[T]) bulk() {}
+
+// ids returns the IDs of the resources of the payload, empty for resources to create.
+func (p *BulkPayload[T]) ids() []string {
+	ids := make([]string, 0, len(p.Data))
+	for _, data := range p.Data {
+		id := ""
+		if data.ID != nil {
+			id = *data.ID
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 type Data[T any, U any] struct {
 	Type string  `json:"type"`
 	ID   *string `json:"id,omitempty"`
@@ -138,6 +283,9 @@ type Data[T any, U any] struct {
 	Attributes    T             `json:"attributes,omitempty"`
 	Links         U             `json:"links,omitempty"`
 	Relationships Relationships `json:"relationships,omitempty"`
+
+	// Embeds holds the raw related resources requested with the "embed" query parameter.
+	Embeds map[string]json.RawMessage `json:"embeds,omitempty"`
 }
 
 type ErrorSource struct {
@@ -179,12 +327,19 @@ type SinglePayload[T any, U any] struct {
 	Errors Errors      `json:"errors,omitempty"`
 
 	transformedData T `json:"-"`
+
+	// CacheStatus tells whether the payload was served from Client.Cache. It is empty without cache.
+	CacheStatus CacheStatus `json:"-"`
 }
 
 func (s *SinglePayload[T, U]) TransformedData() T {
 	return s.transformedData
 }
 
+func (s *SinglePayload[T, U]) setCacheStatus(status CacheStatus) {
+	s.CacheStatus = status
+}
+
 type PaginationMeta struct {
 	Total   int `json:"total"`
 	PerPage int `json:"per_page"`
@@ -206,18 +361,26 @@ type ManyPayload[T any, U any] struct {
 
 	transformedData []T             `json:"-"`
 	PaginationMeta  *PaginationMeta `json:"-"`
+
+	// CacheStatus tells whether the payload was served from Client.Cache. It is empty without cache.
+	CacheStatus CacheStatus `json:"-"`
 }
 
 func (s *ManyPayload[T, U]) TransformedData() []T {
 	return s.transformedData
 }
 
+func (s *ManyPayload[T, U]) setCacheStatus(status CacheStatus) {
+	s.CacheStatus = status
+}
+
 // do performs logic for doSingle and doMany via generic a generic method.
 // HACK: since Go has not supported generics for struct methods (yet), we need to make this standalone.
 func do[T any](c *Client, ctx context.Context, req *http.Request) (*T, error) {
-	resp, err := c.client.Do(req)
+	ctx, info := withRequestInfo(ctx)
+	resp, err := c.execute(ctx, req)
 	if err != nil {
-		return nil, errors.Wrap(err, "http error")
+		return nil, requestError(err)
 	}
 
 	defer resp.Body.Close()
@@ -227,15 +390,94 @@ func do[T any](c *Client, ctx context.Context, req *http.Request) (*T, error) {
 	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
 		return nil, errors.Wrap(err, "error unmarshaling payload")
 	}
+	if setter, ok := interface{}(data).(cacheStatusSetter); ok {
+		setter.setCacheStatus(info.cache)
+	}
 
 	return data, nil
 }
 
+// doNoContent performs a request whose successful response has no payload, such as a deletion.
+func doNoContent(c *Client, ctx context.Context, req *http.Request) error {
+	resp, err := c.execute(ctx, req)
+	if err != nil {
+		return requestError(err)
+	}
+
+	return resp.Body.Close()
+}
+
+// doRaw performs a request whose successful response is not a JSON:API payload,
+// such as file or wiki content. The caller must close the returned body.
+func doRaw(c *Client, ctx context.Context, req *http.Request) (io.ReadCloser, error) {
+	resp, err := c.execute(ctx, req)
+	if err != nil {
+		return nil, requestError(err)
+	}
+
+	return resp.Body, nil
+}
+
+// ErrorResponse is the error of a request which failed with an error status,
+// as seen by middlewares. Its body has been read to decode its JSON:API
+// errors, but can be read again. Service methods return its Errors instead.
+type ErrorResponse struct {
+	Response *http.Response
+	Errors   Errors
+}
+
+func (e *ErrorResponse) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("%s %s: %s: %v", e.Response.Request.Method, RedactURL(e.Response.Request.URL), e.Response.Status, e.Errors)
+	}
+	return fmt.Sprintf("%s %s: %s", e.Response.Request.Method, RedactURL(e.Response.Request.URL), e.Response.Status)
+}
+
+func (e *ErrorResponse) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e.Errors
+}
+
+// checkResponse returns an *ErrorResponse if resp has an error status.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	errResp := &ErrorResponse{Response: resp}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return errResp
+	}
+
+	payload := new(SinglePayload[interface{}, interface{}])
+	if err := json.Unmarshal(body, payload); err == nil {
+		errResp.Errors = payload.Errors
+	}
+	return errResp
+}
+
+// requestError returns the error service methods return for a failed request:
+// the JSON:API errors of an error response, or the wrapped HTTP error.
+func requestError(err error) error {
+	if errResp, ok := err.(*ErrorResponse); ok {
+		if len(errResp.Errors) > 0 {
+			return errResp.Errors
+		}
+		return fmt.Errorf("unexpected response status: %s", errResp.Response.Status)
+	}
+	return errors.Wrap(err, "http error")
+}
+
 func getIDFieldIndex(obj interface{}) int {
 	v := reflect.ValueOf(obj)
 
-	// For this to work, T needs to be a pointer.
-	if v.Type().Kind() != reflect.Pointer {
+	// For this to work, T needs to be a non-nil pointer.
+	if !v.IsValid() || v.Type().Kind() != reflect.Pointer || v.IsNil() {
 		return -1
 	}
 
@@ -265,24 +507,52 @@ func doSingle[T any, U any](c *Client, ctx context.Context, req *http.Request, b
 		return res, res.Errors
 	}
 
+	res.transformedData, err = transformSingle(res.Data, build...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, err
+}
+
+// transformSingle injects the ID into the attributes of obj and transforms it.
+func transformSingle[T any, U any](obj *Data[T, U], build ...TransformDataFn[T, U]) (T, error) {
 	// Inject ID into Attributes, if it exists.
-	if res.Data.ID != nil {
-		idFieldIndex := getIDFieldIndex(res.Data.Attributes)
+	if obj.ID != nil {
+		idFieldIndex := getIDFieldIndex(obj.Attributes)
 		if idFieldIndex != -1 {
-			reflect.ValueOf(res.Data.Attributes).Elem().Field(idFieldIndex).Set(reflect.ValueOf(res.Data.ID).Elem())
+			reflect.ValueOf(obj.Attributes).Elem().Field(idFieldIndex).Set(reflect.ValueOf(obj.ID).Elem())
 		}
 	}
 
 	if len(build) > 0 {
-		res.transformedData, err = build[0](res.Data)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		res.transformedData = res.Data.Attributes
+		return build[0](obj)
 	}
+	return obj.Attributes, nil
+}
 
-	return res, err
+// decodeEmbed decodes the single resource embedded under key in embeds.
+// The zero value of T is returned if there is no such resource.
+func decodeEmbed[T any, U any](embeds map[string]json.RawMessage, key string, build ...TransformDataFn[T, U]) (T, error) {
+	var zero T
+
+	raw, ok := embeds[key]
+	if !ok {
+		return zero, nil
+	}
+
+	var payload SinglePayload[T, U]
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return zero, errors.Wrapf(err, "error unmarshaling embedded %s", key)
+	}
+	if len(payload.Errors) > 0 {
+		return zero, payload.Errors
+	}
+	if payload.Data == nil {
+		return zero, nil
+	}
+
+	return transformSingle(payload.Data, build...)
 }
 
 // doMany performs a request for a paginated payload.
@@ -298,7 +568,7 @@ func doMany[T any, U any](c *Client, ctx context.Context, req *http.Request, bui
 	}
 
 	// Inject ID into T, if it exists.
-	if len(res.transformedData) > 0 {
+	if len(res.Data) > 0 {
 		idFieldIndex := getIDFieldIndex(res.Data[0].Attributes)
 
 		if idFieldIndex != -1 {
@@ -361,6 +631,72 @@ type ListOptions struct {
 	Filter map[string]string `url:"-"`
 }
 
+func (o *ListOptions) filters() map[string]string {
+	return o.Filter
+}
+
+// getResource performs a GET request for a single resource.
+func getResource[T any, U any](c *Client, ctx context.Context, u string, build ...TransformDataFn[T, U]) (T, *SinglePayload[T, U], error) {
+	var zero T
+
+	req, err := c.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return zero, nil, err
+	}
+
+	res, err := doSingle(c, ctx, req, build...)
+	if err != nil {
+		return zero, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// listAllResources fetches every page of a list endpoint.
+func listAllResources[T any, U any](c *Client, ctx context.Context, u string, filter map[string]string, build ...TransformDataFn[T, U]) ([]T, error) {
+	opts := &ListOptions{Page: 1, PerPage: 100, Filter: filter}
+
+	var all []T
+	for {
+		objs, res, err := listResources(c, ctx, u, opts, build...)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, objs...)
+
+		if len(objs) == 0 || res.PaginationMeta == nil || len(all) >= res.PaginationMeta.Total {
+			return all, nil
+		}
+		opts.Page++
+	}
+}
+
+// listResources performs a GET request on a list endpoint. opts is a possibly
+// nil pointer to a struct embedding ListOptions.
+func listResources[T any, U any](c *Client, ctx context.Context, u string, opts interface{}, build ...TransformDataFn[T, U]) ([]T, *ManyPayload[T, U], error) {
+	var filter map[string]string
+	if f, ok := opts.(interface{ filters() map[string]string }); ok && !reflect.ValueOf(opts).IsNil() {
+		filter = f.filters()
+	}
+
+	u, err := addOptionsWithFilter(u, opts, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doMany(c, ctx, req, build...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
 // addOptions adds the parameters in opts as URL query parameters to s. opts
 // must be a struct whose fields may contain "url" tags.
 func addOptions(s string, opts interface{}, additionalQueries ...map[string]string) (string, error) {
@@ -374,11 +710,17 @@ func addOptions(s string, opts interface{}, additionalQueries ...map[string]stri
 		return s, err
 	}
 
-	qs, err := query.Values(opts)
+	values, err := query.Values(opts)
 	if err != nil {
 		return s, err
 	}
 
+	// Keep the parameters already in s, such as embed.
+	qs := u.Query()
+	for k, v := range values {
+		qs[k] = append(qs[k], v...)
+	}
+
 	u.RawQuery = qs.Encode()
 	return u.String(), nil
 
@@ -393,13 +735,17 @@ func addOptionsWithFilter(s string, opts interface{}, additionalQueries ...map[s
 		return s, err
 	}
 
-	var qs url.Values
+	// Keep the parameters already in s, such as embed.
+	qs := u.Query()
 	v := reflect.ValueOf(opts)
 	if !(v.Kind() == reflect.Ptr && v.IsNil()) {
-		qs, err = query.Values(opts)
+		values, err := query.Values(opts)
 		if err != nil {
 			return s, err
 		}
+		for k, v := range values {
+			qs[k] = append(qs[k], v...)
+		}
 	}
 
 	for _, q := range additionalQueries {
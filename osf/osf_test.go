@@ -33,6 +33,18 @@ func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown fun
 	return client, mux, server.URL, server.Close
 }
 
+// setupServer is like setup, but points the client at a local test server, so
+// that handlers registered on mux (with a leading slash) serve the requests.
+func setupServer() (client *Client, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client = NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, mux, server.Close
+}
+
 func testMethod(t *testing.T, r *http.Request, want string) {
 	t.Helper()
 	if got := r.Method; got != want {
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 )
 
 var (
@@ -24,11 +25,6 @@ type PreprintLicenseRecord struct {
 	Year             string   `json:"year"`
 }
 
-type Subject struct {
-	ID   string `json:"id"`
-	Text string `json:"text"`
-}
-
 type PreprintLinks struct {
 	Self        *string `json:"self"`
 	Html        *string `json:"html"`
@@ -71,6 +67,7 @@ type Preprint struct {
 
 type PreprintRequest struct {
 	PreprintProviderID          string                 `json:"-"`
+	LicenseID                   string                 `json:"-"`
 	Title                       *string                `json:"title,omitempty"`
 	Description                 *string                `json:"description,omitempty"`
 	IsPublished                 *bool                  `json:"is_published,omitempty"`
@@ -91,8 +88,32 @@ type PreprintRequest struct {
 	PreregLinks                 *[]string              `json:"prereg_links,omitempty"`
 }
 
+// relationships returns a copy of relationships with the relationships set
+// through input fields, such as LicenseID, added.
+func (input *PreprintRequest) relationships(relationships Relationships) Relationships {
+	if input == nil || input.LicenseID == "" {
+		return relationships
+	}
+	merged := make(Relationships, len(relationships)+1)
+	for name, relationship := range relationships {
+		merged[name] = relationship
+	}
+	merged["license"] = Relationship{
+		Data: &Data[interface{}, interface{}]{
+			ID:   &input.LicenseID,
+			Type: TypeLicenses,
+		},
+	}
+	return merged
+}
+
 type PreprintsListOptions struct {
 	ListOptions
+
+	// DateModifiedSince only lists the preprints modified at or after this time.
+	DateModifiedSince *time.Time `url:"filter[date_modified][gte],omitempty" layout:"2006-01-02T15:04:05.999999Z07:00"`
+	// Sort orders the preprints by a field, e.g. "date_modified", or "-date_modified" for descending order.
+	Sort string `url:"sort,omitempty"`
 }
 
 func transformPreprint(raw *Data[*Preprint, *PreprintLinks]) (*Preprint, error) {
@@ -150,14 +171,14 @@ func (s *PreprintsService) CreatePreprint(ctx context.Context, input *PreprintRe
 		Data: &Data[*PreprintRequest, interface{}]{
 			Type:       TypePreprints,
 			Attributes: input,
-			Relationships: Relationships{
+			Relationships: input.relationships(Relationships{
 				"provider": Relationship{
 					Data: &Data[interface{}, interface{}]{
 						ID:   &input.PreprintProviderID,
 						Type: TypeProviders,
 					},
 				},
-			},
+			}),
 		},
 	}
 
@@ -184,10 +205,19 @@ func (s *PreprintsService) CreatePreprint(ctx context.Context, input *PreprintRe
 	values.Add("name", primaryFile.Name())
 	fileUploadURL.RawQuery = values.Encode()
 
-	fileReq, err := http.NewRequest(http.MethodPut, fileUploadURL.String(), primaryFile)
+	var fileSize int64 = -1
+	if info, err := primaryFile.Stat(); err == nil {
+		fileSize = info.Size()
+	}
+	body := newProgressReader(primaryFile, s.client.progressReporter(ctx), TransferUpload, primaryFile.Name(), fileSize)
+
+	fileReq, err := http.NewRequest(http.MethodPut, fileUploadURL.String(), body)
 	if err != nil {
 		return nil, nil, err
 	}
+	if fileSize >= 0 {
+		fileReq.ContentLength = fileSize
+	}
 	fileRes, err := doSingle(s.client, ctx, fileReq, transformFile)
 	if err != nil {
 		return nil, nil, err
@@ -237,7 +267,7 @@ func (s *PreprintsService) UpdatePreprint(ctx context.Context, id string, input
 			Type:          TypePreprints,
 			ID:            &id,
 			Attributes:    input,
-			Relationships: relationships,
+			Relationships: input.relationships(relationships),
 		},
 	}
 
@@ -254,6 +284,16 @@ func (s *PreprintsService) UpdatePreprint(ctx context.Context, id string, input
 	return res.TransformedData(), res, nil
 }
 
+// UpdatePreprintLicense sets the license of a preprint, along with its license
+// record, after checking the record with ValidateLicenseRecord.
+func (s *PreprintsService) UpdatePreprintLicense(ctx context.Context, id string, license *License, record *PreprintLicenseRecord) (*Preprint, *SinglePayload[*Preprint, *PreprintLinks], error) {
+	if err := ValidateLicenseRecord(license, record); err != nil {
+		return nil, nil, err
+	}
+
+	return s.UpdatePreprint(ctx, id, &PreprintRequest{LicenseID: license.ID, LicenseRecord: record}, nil)
+}
+
 func (s *PreprintsService) GetPreprintPrimaryFileByID(ctx context.Context, id string) (*File, *SinglePayload[*File, *FileLinks], error) {
 	_, res, err := s.GetPreprintByID(ctx, id)
 	if err != nil {
@@ -273,3 +313,19 @@ func (s *PreprintsService) GetPreprintPrimaryFileByID(ctx context.Context, id st
 
 	return s.client.Files.GetFileByID(ctx, fileID)
 }
+
+// GetPreprintsByID fetches many preprints concurrently, listing them with
+// filter[id] to reduce round trips, and getting the ones which cannot be
+// listed, e.g. unpublished ones, one by one. The results are in the order of
+// ids, each with its preprint or its error. The returned error is only set if
+// ctx is done.
+func (s *PreprintsService) GetPreprintsByID(ctx context.Context, ids []string, opts *BulkOptions) ([]*BulkResult[*Preprint], error) {
+	get := func(ctx context.Context, id string) (*Preprint, error) {
+		preprint, _, err := s.GetPreprintByID(ctx, id)
+		return preprint, err
+	}
+	idOf := func(preprint *Preprint) string {
+		return preprint.ID
+	}
+	return getResourcesByID(s.client, ctx, "preprints/", ids, opts, idOf, get, transformPreprint)
+}
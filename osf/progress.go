@@ -0,0 +1,199 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TransferKind tells whether a Progress report belongs to an upload or a download.
+type TransferKind string
+
+const (
+	TransferUpload   TransferKind = "upload"
+	TransferDownload TransferKind = "download"
+)
+
+// Progress is a snapshot of an ongoing upload or download.
+type Progress struct {
+	Kind TransferKind
+	Name string
+
+	// Transferred is the number of bytes sent or received so far.
+	Transferred int64
+	// Total is the expected size in bytes, or -1 when it is unknown.
+	Total int64
+	// Rate is the average transfer rate in bytes per second.
+	Rate float64
+	// ETA is the estimated remaining time, or 0 when it cannot be estimated.
+	ETA time.Duration
+	// Done is true for the last report of a transfer.
+	Done bool
+}
+
+// ProgressReporter receives progress reports for file uploads and downloads.
+// A reporter can be attached to every transfer of a client through
+// Client.ProgressReporter, or to a single call through WithProgressReporter.
+type ProgressReporter interface {
+	ReportProgress(p Progress)
+}
+
+// ProgressReporterFunc adapts an ordinary function to a ProgressReporter.
+type ProgressReporterFunc func(p Progress)
+
+func (f ProgressReporterFunc) ReportProgress(p Progress) {
+	f(p)
+}
+
+type progressReporterKey struct{}
+
+// WithProgressReporter returns a copy of ctx that makes transfers performed with
+// it report to r, overriding Client.ProgressReporter.
+func WithProgressReporter(ctx context.Context, r ProgressReporter) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, r)
+}
+
+// progressReporter returns the reporter attached to ctx, falling back to the client one.
+func (c *Client) progressReporter(ctx context.Context) ProgressReporter {
+	if r, ok := ctx.Value(progressReporterKey{}).(ProgressReporter); ok && r != nil {
+		return r
+	}
+	return c.ProgressReporter
+}
+
+// progressReader wraps an io.Reader and reports the bytes read through it.
+type progressReader struct {
+	r        io.Reader
+	reporter ProgressReporter
+	progress Progress
+	start    time.Time
+	finished bool
+}
+
+// newProgressReader returns r untouched if there is no reporter to report to.
+func newProgressReader(r io.Reader, reporter ProgressReporter, kind TransferKind, name string, total int64) io.Reader {
+	if reporter == nil {
+		return r
+	}
+	if total <= 0 {
+		total = -1
+	}
+	return &progressReader{
+		r:        r,
+		reporter: reporter,
+		progress: Progress{Kind: kind, Name: name, Total: total},
+		start:    time.Now(),
+	}
+}
+
+func (pr *progressReader) Read(p []byte) (int, error) {
+	n, err := pr.r.Read(p)
+	pr.progress.Transferred += int64(n)
+	if n > 0 || err == io.EOF {
+		pr.report(err == io.EOF)
+	}
+	return n, err
+}
+
+// Close reports the end of the transfer if the reader was not drained to io.EOF,
+// and closes the underlying reader when it is an io.Closer.
+func (pr *progressReader) Close() error {
+	pr.report(true)
+	if c, ok := pr.r.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (pr *progressReader) report(done bool) {
+	if pr.finished {
+		return
+	}
+	pr.finished = done
+
+	p := pr.progress
+	p.Done = done
+	if elapsed := time.Since(pr.start).Seconds(); elapsed > 0 {
+		p.Rate = float64(p.Transferred) / elapsed
+	}
+	if p.Total > 0 && p.Rate > 0 && p.Transferred < p.Total {
+		p.ETA = time.Duration(float64(p.Total-p.Transferred) / p.Rate * float64(time.Second))
+	}
+	pr.reporter.ReportProgress(p)
+}
+
+// ProgressBar is a ProgressReporter rendering a single-line progress bar to a
+// terminal, such as os.Stderr.
+type ProgressBar struct {
+	// Width is the number of cells of the bar itself. Defaults to 30.
+	Width int
+	// Interval is the minimum time between two redraws. Defaults to 100ms.
+	Interval time.Duration
+
+	mu       sync.Mutex
+	w        io.Writer
+	lastDraw time.Time
+}
+
+// NewProgressBar returns a ProgressBar writing to w.
+func NewProgressBar(w io.Writer) *ProgressBar {
+	return &ProgressBar{w: w, Width: 30, Interval: 100 * time.Millisecond}
+}
+
+func (b *ProgressBar) ReportProgress(p Progress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if !p.Done && now.Sub(b.lastDraw) < b.Interval {
+		return
+	}
+	b.lastDraw = now
+
+	width := b.Width
+	if width <= 0 {
+		width = 30
+	}
+
+	var line string
+	if p.Total > 0 {
+		ratio := float64(p.Transferred) / float64(p.Total)
+		if ratio > 1 {
+			ratio = 1
+		}
+		filled := int(ratio * float64(width))
+		line = fmt.Sprintf("%s [%s%s] %3.0f%% %s/%s %s/s",
+			p.Name,
+			strings.Repeat("=", filled), strings.Repeat(" ", width-filled),
+			ratio*100,
+			formatBytes(p.Transferred), formatBytes(p.Total),
+			formatBytes(int64(p.Rate)))
+		if p.ETA > 0 {
+			line += " ETA " + p.ETA.Round(time.Second).String()
+		}
+	} else {
+		line = fmt.Sprintf("%s %s %s/s", p.Name, formatBytes(p.Transferred), formatBytes(int64(p.Rate)))
+	}
+
+	// Pad with spaces to wipe leftovers of a longer previous line.
+	fmt.Fprintf(b.w, "\r%-80s", line)
+	if p.Done {
+		fmt.Fprintln(b.w)
+	}
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
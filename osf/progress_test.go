@@ -0,0 +1,72 @@
+package osf
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesService_DownloadFileProgress(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	content := strings.Repeat("a", 4096)
+	mux.HandleFunc("/download/abc", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Write([]byte(content))
+	})
+
+	var clientReports, callReports []Progress
+	client.ProgressReporter = ProgressReporterFunc(func(p Progress) {
+		clientReports = append(clientReports, p)
+	})
+
+	dir := t.TempDir()
+	file := &File{
+		Name:      "paper.pdf",
+		Size:      int64(len(content)),
+		FileLinks: &FileLinks{Download: StringPointer(client.BaseURL.String() + "download/abc")},
+	}
+
+	ctx := context.Background()
+	if err := client.Files.DownloadFile(ctx, dir, "", file); err != nil {
+		t.Fatalf("Files.DownloadFile returned error: %v", err)
+	}
+
+	ctx = WithProgressReporter(ctx, ProgressReporterFunc(func(p Progress) {
+		callReports = append(callReports, p)
+	}))
+	if err := client.Files.DownloadFile(ctx, dir, "copy.pdf", file); err != nil {
+		t.Fatalf("Files.DownloadFile returned error: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "copy.pdf"))
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(got))
+
+	for _, reports := range [][]Progress{clientReports, callReports} {
+		if assert.NotEmpty(t, reports) {
+			last := reports[len(reports)-1]
+			assert.True(t, last.Done)
+			assert.Equal(t, TransferDownload, last.Kind)
+			assert.Equal(t, int64(len(content)), last.Transferred)
+			assert.Equal(t, int64(len(content)), last.Total)
+		}
+	}
+	assert.Equal(t, "paper.pdf", clientReports[0].Name)
+	assert.Equal(t, "copy.pdf", callReports[0].Name)
+}
+
+func TestProgressBar(t *testing.T) {
+	var sb strings.Builder
+	bar := NewProgressBar(&sb)
+	bar.ReportProgress(Progress{Name: "paper.pdf", Transferred: 512, Total: 1024, Done: true})
+
+	assert.Contains(t, sb.String(), "paper.pdf [===============               ]  50% 512B/1.0KiB")
+	assert.True(t, strings.HasSuffix(sb.String(), "\n"))
+}
@@ -0,0 +1,50 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+)
+
+type RegistrationsService service
+
+// Registration is a frozen, timestamped version of a node.
+type Registration struct {
+	ID string `json:"id"`
+
+	Title                       string       `json:"title"`
+	Description                 string       `json:"description"`
+	Category                    string       `json:"category"`
+	DateCreated                 *Time        `json:"date_created"`
+	DateModified                *Time        `json:"date_modified"`
+	DateRegistered              *Time        `json:"date_registered"`
+	DateWithdrawn               *Time        `json:"date_withdrawn"`
+	EmbargoEndDate              *Time        `json:"embargo_end_date"`
+	Withdrawn                   bool         `json:"withdrawn"`
+	WithdrawalJustification     *string      `json:"withdrawal_justification"`
+	PendingRegistrationApproval bool         `json:"pending_registration_approval"`
+	PendingEmbargoApproval      bool         `json:"pending_embargo_approval"`
+	PendingWithdrawal           bool         `json:"pending_withdrawal"`
+	RegistrationSupplement      *string      `json:"registration_supplement"`
+	ArticleDOI                  *string      `json:"article_doi"`
+	Tags                        []string     `json:"tags"`
+	NodeLicense                 *NodeLicense `json:"node_license"`
+	Public                      bool         `json:"public"`
+	CurrentUserPermissions      []string     `json:"current_user_permissions"`
+
+	Links *RegistrationLinks `json:"-"`
+}
+
+type RegistrationLinks struct {
+	Self *string `json:"self"`
+	Html *string `json:"html"`
+}
+
+func transformRegistration(raw *Data[*Registration, *RegistrationLinks]) (*Registration, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func (s *RegistrationsService) GetRegistrationByID(ctx context.Context, id string) (*Registration, *SinglePayload[*Registration, *RegistrationLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("registrations/%s/", id), transformRegistration)
+}
@@ -0,0 +1,160 @@
+package osf
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether and when failed requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a request.
+	MaxRetries int
+	// MinBackoff is the wait before the first retry, doubled for every following one up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// CheckRetry reports whether a request should be retried after its
+	// response or error. Defaults to DefaultCheckRetry.
+	CheckRetry func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy retries requests 3 times, waiting from 1 to 30 seconds.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: time.Second,
+	MaxBackoff: 30 * time.Second,
+}
+
+// DefaultCheckRetry retries requests which were throttled, and idempotent
+// requests which failed because of the network or the server.
+func DefaultCheckRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && resp.StatusCode != http.StatusNotImplemented
+}
+
+func (p *RetryPolicy) checkRetry(req *http.Request, resp *http.Response, err error) bool {
+	if p.CheckRetry != nil {
+		return p.CheckRetry(req, resp, err)
+	}
+	return DefaultCheckRetry(req, resp, err)
+}
+
+// backoff returns the wait before the retry following attempt, the first
+// attempt being 0. A Retry-After header of resp takes precedence.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if after := parseRetryAfter(resp.Header.Get("Retry-After")); after > 0 {
+			if p.MaxBackoff > 0 && after > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return after
+		}
+	}
+
+	wait := p.MinBackoff << attempt
+	if p.MaxBackoff > 0 && (wait > p.MaxBackoff || wait <= 0) {
+		wait = p.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Add up to 10% of jitter so that concurrent clients don't retry in lockstep.
+	return wait + time.Duration(rand.Int63n(int64(wait)/10+1))
+}
+
+// parseRetryAfter parses a Retry-After header, either in seconds or an HTTP date.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// send performs req, waiting for the rate limiter and retrying it according to
+// the retry policy. It returns an *ErrorResponse if the response has an error status.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	req = req.WithContext(ctx)
+	_, info := withRequestInfo(ctx)
+
+	for attempt := 0; ; attempt++ {
+		info.attempts = attempt + 1
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.client.Do(req)
+
+		policy := c.RetryPolicy
+		if policy == nil || attempt >= policy.MaxRetries || !policy.checkRetry(req, resp, err) ||
+			// A body which was already sent cannot be sent again without GetBody.
+			(req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+			if err != nil {
+				return nil, err
+			}
+			return resp, checkResponse(resp)
+		}
+
+		wait := policy.backoff(attempt, resp)
+		c.logger().Warn("retrying OSF request",
+			"method", req.Method,
+			"url", RedactURL(req.URL),
+			"attempt", attempt+1,
+			"status", statusCode(resp),
+			"error", err,
+			"backoff", wait,
+		)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+func statusCode(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,76 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = &RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestClient_RetryGet(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy
+
+	attempts := 0
+	mux.HandleFunc("/licenses/l1/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"id":"l1","type":"licenses","attributes":{"name":"CC-By"}}}`)
+	})
+
+	license, _, err := client.Licenses.GetLicenseByID(context.Background(), "l1")
+	if err != nil {
+		t.Fatalf("Licenses.GetLicenseByID returned error: %v", err)
+	}
+	assert.Equal(t, "CC-By", license.Name)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestClient_RetryPost(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy
+
+	var bodies []string
+	mux.HandleFunc("/tokens/", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		switch len(bodies) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			// Not idempotent, so not retried.
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"errors":[{"detail":"boom"}]}`)
+		}
+	})
+
+	_, _, err := client.Tokens.CreateToken(context.Background(), &TokenRequest{Name: StringPointer("ci")})
+	assert.Error(t, err)
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 4 * time.Second}
+
+	assert.GreaterOrEqual(t, policy.backoff(0, nil), time.Second)
+	assert.Less(t, policy.backoff(1, nil), 3*time.Second)
+	assert.GreaterOrEqual(t, policy.backoff(10, nil), 4*time.Second)
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"2"}}}
+	assert.Equal(t, 2*time.Second, policy.backoff(0, resp))
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, 4*time.Second, policy.backoff(0, resp))
+}
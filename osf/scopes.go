@@ -0,0 +1,39 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+)
+
+type ScopesService service
+
+type ScopeLinks struct {
+	Self *string `json:"self"`
+}
+
+// Scope is a permission which can be granted to a token, such as "osf.full_read".
+type Scope struct {
+	ID string `json:"id"`
+
+	Description string `json:"description"`
+
+	Links *ScopeLinks `json:"-"`
+}
+
+type ScopesListOptions struct {
+	ListOptions
+}
+
+func transformScope(raw *Data[*Scope, *ScopeLinks]) (*Scope, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func (s *ScopesService) ListScopes(ctx context.Context, opts *ScopesListOptions) ([]*Scope, *ManyPayload[*Scope, *ScopeLinks], error) {
+	return listResources(s.client, ctx, "scopes/", opts, transformScope)
+}
+
+func (s *ScopesService) GetScopeByID(ctx context.Context, id string) (*Scope, *SinglePayload[*Scope, *ScopeLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("scopes/%s/", id), transformScope)
+}
@@ -0,0 +1,87 @@
+package osf
+
+import (
+	"context"
+	"net/http"
+)
+
+type SearchService service
+
+type SearchOptions struct {
+	ListOptions
+
+	// Query is the text to search for.
+	Query string `url:"q,omitempty"`
+}
+
+type searchSummaryPayload struct {
+	SearchFields map[string]struct {
+		Related *Link `json:"related"`
+	} `json:"search_fields"`
+	Errors Errors `json:"errors,omitempty"`
+}
+
+// Search returns the number of results of a query for each resource type,
+// such as "preprints", "projects" or "users".
+func (s *SearchService) Search(ctx context.Context, query string) (map[string]int, error) {
+	u, err := addOptions("search/", &SearchOptions{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := do[searchSummaryPayload](s.client, ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Errors) > 0 {
+		return nil, res.Errors
+	}
+
+	totals := make(map[string]int, len(res.SearchFields))
+	for field, links := range res.SearchFields {
+		if links.Related == nil {
+			continue
+		}
+		if total, ok := links.Related.Meta["total"].(float64); ok {
+			totals[field] = int(total)
+		}
+	}
+	return totals, nil
+}
+
+func (s *SearchService) SearchPreprints(ctx context.Context, opts *SearchOptions) ([]*Preprint, *ManyPayload[*Preprint, *PreprintLinks], error) {
+	return listResources(s.client, ctx, "search/preprints/", opts, transformPreprint)
+}
+
+func (s *SearchService) SearchProjects(ctx context.Context, opts *SearchOptions) ([]*Node, *ManyPayload[*Node, *NodeLinks], error) {
+	return listResources(s.client, ctx, "search/projects/", opts, transformNode)
+}
+
+func (s *SearchService) SearchComponents(ctx context.Context, opts *SearchOptions) ([]*Node, *ManyPayload[*Node, *NodeLinks], error) {
+	return listResources(s.client, ctx, "search/components/", opts, transformNode)
+}
+
+func (s *SearchService) SearchRegistrations(ctx context.Context, opts *SearchOptions) ([]*Registration, *ManyPayload[*Registration, *RegistrationLinks], error) {
+	return listResources(s.client, ctx, "search/registrations/", opts, transformRegistration)
+}
+
+func (s *SearchService) SearchUsers(ctx context.Context, opts *SearchOptions) ([]*User, *ManyPayload[*User, *UserLinks], error) {
+	return listResources(s.client, ctx, "search/users/", opts, transformUser)
+}
+
+func (s *SearchService) SearchFiles(ctx context.Context, opts *SearchOptions) ([]*File, *ManyPayload[*File, *FileLinks], error) {
+	return listResources(s.client, ctx, "search/files/", opts, transformFile)
+}
+
+func (s *SearchService) SearchInstitutions(ctx context.Context, opts *SearchOptions) ([]*Institution, *ManyPayload[*Institution, *InstitutionLinks], error) {
+	return listResources(s.client, ctx, "search/institutions/", opts, transformInstitution)
+}
+
+func (s *SearchService) SearchCollections(ctx context.Context, opts *SearchOptions) ([]*Collection, *ManyPayload[*Collection, *CollectionLinks], error) {
+	return listResources(s.client, ctx, "search/collections/", opts, transformCollection)
+}
@@ -0,0 +1,51 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchService_SearchPreprints(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/search/preprints/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "replication", r.URL.Query().Get("q"))
+		assert.Equal(t, "2", r.URL.Query().Get("page[number]"))
+		fmt.Fprint(w, `{"data":[
+			{"id":"xfdsr","type":"preprints","attributes":{"title":"A replication study"},"links":{"html":"https://osf.io/xfdsr/"}}
+		],"links":{"meta":{"total":11,"per_page":10}}}`)
+	})
+	mux.HandleFunc("/search/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"search_fields":{
+			"preprints":{"related":{"href":"https://api.osf.io/v2/search/preprints/?q=replication","meta":{"total":11}}},
+			"users":{"related":{"href":"https://api.osf.io/v2/search/users/?q=replication","meta":{"total":0}}}
+		}}`)
+	})
+
+	ctx := context.Background()
+	preprints, res, err := client.Search.SearchPreprints(ctx, &SearchOptions{
+		ListOptions: ListOptions{Page: 2},
+		Query:       "replication",
+	})
+	if err != nil {
+		t.Fatalf("Search.SearchPreprints returned error: %v", err)
+	}
+	assert.Len(t, preprints, 1)
+	assert.Equal(t, "xfdsr", preprints[0].ID)
+	assert.Equal(t, "https://osf.io/xfdsr/", *preprints[0].Links.Html)
+	assert.Equal(t, 11, res.PaginationMeta.Total)
+	assert.Equal(t, 2, res.PaginationMeta.Page)
+
+	totals, err := client.Search.Search(ctx, "replication")
+	if err != nil {
+		t.Fatalf("Search.Search returned error: %v", err)
+	}
+	assert.Equal(t, map[string]int{"preprints": 11, "users": 0}, totals)
+}
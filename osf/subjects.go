@@ -0,0 +1,216 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SubjectPathSeparator separates the levels of a human-readable subject path,
+// e.g. "Social and Behavioral Sciences > Psychology".
+const SubjectPathSeparator = ">"
+
+type SubjectsService service
+
+// Subject is a node of the OSF subject hierarchy (taxonomy). Subjects embedded
+// in a Preprint only carry ID and Text.
+type Subject struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+
+	ChildCount   int     `json:"child_count,omitempty"`
+	TaxonomyName string  `json:"taxonomy_name,omitempty"`
+	ShareTitle   *string `json:"share_title,omitempty"`
+	Path         string  `json:"path,omitempty"`
+
+	// ParentID is the ID of the parent subject, or nil for a top-level subject.
+	ParentID *string `json:"-"`
+
+	Links *SubjectLinks `json:"-"`
+}
+
+type SubjectLinks struct {
+	Self *string `json:"self"`
+}
+
+type SubjectsListOptions struct {
+	ListOptions
+}
+
+func transformSubject(raw *Data[*Subject, *SubjectLinks]) (*Subject, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["parent"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.ParentID = rel.Data.ID
+	}
+	return obj, nil
+}
+
+func (s *SubjectsService) listSubjects(ctx context.Context, u string, opts *SubjectsListOptions) ([]*Subject, *ManyPayload[*Subject, *SubjectLinks], error) {
+	return listResources(s.client, ctx, u, opts, transformSubject)
+}
+
+// listAllSubjects fetches every page of a subject list endpoint.
+func (s *SubjectsService) listAllSubjects(ctx context.Context, u string, filter map[string]string) ([]*Subject, error) {
+	return listAllResources(s.client, ctx, u, filter, transformSubject)
+}
+
+func (s *SubjectsService) getSubject(ctx context.Context, u string) (*Subject, *SinglePayload[*Subject, *SubjectLinks], error) {
+	return getResource(s.client, ctx, u, transformSubject)
+}
+
+// ListTaxonomies lists the general OSF taxonomy. Filter on "text" or "parents" through opts.Filter.
+func (s *SubjectsService) ListTaxonomies(ctx context.Context, opts *SubjectsListOptions) ([]*Subject, *ManyPayload[*Subject, *SubjectLinks], error) {
+	return s.listSubjects(ctx, "taxonomies/", opts)
+}
+
+func (s *SubjectsService) GetTaxonomyByID(ctx context.Context, id string) (*Subject, *SinglePayload[*Subject, *SubjectLinks], error) {
+	return s.getSubject(ctx, fmt.Sprintf("taxonomies/%s/", id))
+}
+
+// ListProviderSubjects lists the subjects used by a preprint provider.
+// Top-level subjects can be listed with the filter "parent": "null".
+func (s *SubjectsService) ListProviderSubjects(ctx context.Context, providerID string, opts *SubjectsListOptions) ([]*Subject, *ManyPayload[*Subject, *SubjectLinks], error) {
+	return s.listSubjects(ctx, fmt.Sprintf("providers/preprints/%s/subjects/", providerID), opts)
+}
+
+func (s *SubjectsService) GetSubjectByID(ctx context.Context, id string) (*Subject, *SinglePayload[*Subject, *SubjectLinks], error) {
+	return s.getSubject(ctx, fmt.Sprintf("subjects/%s/", id))
+}
+
+func (s *SubjectsService) ListSubjectChildren(ctx context.Context, id string, opts *SubjectsListOptions) ([]*Subject, *ManyPayload[*Subject, *SubjectLinks], error) {
+	return s.listSubjects(ctx, fmt.Sprintf("subjects/%s/children/", id), opts)
+}
+
+// GetSubjectParents returns the ancestors of a subject, starting from the top-level one.
+func (s *SubjectsService) GetSubjectParents(ctx context.Context, id string) ([]*Subject, error) {
+	subject, _, err := s.GetSubjectByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var parents []*Subject
+	for subject.ParentID != nil {
+		subject, _, err = s.GetSubjectByID(ctx, *subject.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parents = append([]*Subject{subject}, parents...)
+	}
+
+	return parents, nil
+}
+
+// SearchSubjects returns the subjects of a preprint provider whose text contains text.
+// If providerID is empty, the general OSF taxonomy is searched instead.
+func (s *SubjectsService) SearchSubjects(ctx context.Context, providerID string, text string) ([]*Subject, error) {
+	u := "taxonomies/"
+	if providerID != "" {
+		u = fmt.Sprintf("providers/preprints/%s/subjects/", providerID)
+	}
+	return s.listAllSubjects(ctx, u, map[string]string{"text": text})
+}
+
+// ResolveSubjectPath converts a human-readable subject path such as
+// "Social and Behavioral Sciences > Psychology" into the list of subject IDs,
+// from the top-level subject down, of the given provider. Texts are matched
+// case-insensitively. The resolved path is validated against
+// provider.SubjectsAcceptable.
+func (s *SubjectsService) ResolveSubjectPath(ctx context.Context, provider *PreprintProvider, path string) ([]string, error) {
+	var texts []string
+	for _, text := range strings.Split(path, SubjectPathSeparator) {
+		if text = strings.TrimSpace(text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("empty subject path %q", path)
+	}
+
+	candidates, err := s.listAllSubjects(ctx, fmt.Sprintf("providers/preprints/%s/subjects/", provider.ID), map[string]string{"parent": "null"})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(texts))
+	for i, text := range texts {
+		var match *Subject
+		for _, candidate := range candidates {
+			if strings.EqualFold(candidate.Text, text) {
+				match = candidate
+				break
+			}
+		}
+		if match == nil {
+			return nil, fmt.Errorf("subject %q not found in path %q of provider %s", text, path, provider.ID)
+		}
+		ids = append(ids, match.ID)
+
+		if i < len(texts)-1 {
+			candidates, err = s.listAllSubjects(ctx, fmt.Sprintf("subjects/%s/children/", match.ID), nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := ValidateSubjectHierarchy(provider, ids); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// ResolveSubjectPaths resolves several subject paths with ResolveSubjectPath,
+// in the form expected by PreprintRequest.Subjects.
+func (s *SubjectsService) ResolveSubjectPaths(ctx context.Context, provider *PreprintProvider, paths ...string) ([][]string, error) {
+	hierarchies := make([][]string, 0, len(paths))
+	for _, path := range paths {
+		ids, err := s.ResolveSubjectPath(ctx, provider, path)
+		if err != nil {
+			return nil, err
+		}
+		hierarchies = append(hierarchies, ids)
+	}
+	return hierarchies, nil
+}
+
+// ValidateSubjectHierarchy checks that every subject ID of a hierarchy, given
+// from the top-level subject down, is accepted by the provider. A subject is
+// accepted if it is listed in one of provider.SubjectsAcceptable, or if one of
+// its ancestors ends a rule which includes all children. A provider without
+// SubjectsAcceptable accepts any subject.
+func ValidateSubjectHierarchy(provider *PreprintProvider, ids []string) error {
+	if len(provider.SubjectsAcceptable) == 0 {
+		return nil
+	}
+
+	listed := make(map[string]bool)
+	withChildren := make(map[string]bool)
+	for _, rule := range provider.SubjectsAcceptable {
+		for _, id := range rule.TaxonomiesID {
+			listed[id] = true
+		}
+		if rule.IncludeAllChildren && len(rule.TaxonomiesID) > 0 {
+			withChildren[rule.TaxonomiesID[len(rule.TaxonomiesID)-1]] = true
+		}
+	}
+
+	for i, id := range ids {
+		if listed[id] {
+			continue
+		}
+		accepted := false
+		for _, ancestor := range ids[:i] {
+			if withChildren[ancestor] {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return fmt.Errorf("subject %s is not acceptable for provider %s", id, provider.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubjectsService_ResolveSubjectPath(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/providers/preprints/psyarxiv/subjects/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "null", r.URL.Query().Get("filter[parent]"))
+		fmt.Fprint(w, `{"data":[
+			{"id":"s1","type":"subjects","attributes":{"text":"Life Sciences"}},
+			{"id":"s2","type":"subjects","attributes":{"text":"Social and Behavioral Sciences"}}
+		],"links":{"meta":{"total":2,"per_page":100}}}`)
+	})
+	mux.HandleFunc("/subjects/s2/children/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":"s21","type":"subjects","attributes":{"text":"Psychology"},"relationships":{"parent":{"data":{"id":"s2","type":"subjects"}}}}
+		],"links":{"meta":{"total":1,"per_page":100}}}`)
+	})
+
+	provider := &PreprintProvider{ID: "psyarxiv"}
+	ctx := context.Background()
+
+	ids, err := client.Subjects.ResolveSubjectPath(ctx, provider, "social and behavioral sciences > Psychology")
+	if err != nil {
+		t.Fatalf("Subjects.ResolveSubjectPath returned error: %v", err)
+	}
+	assert.Equal(t, []string{"s2", "s21"}, ids)
+
+	_, err = client.Subjects.ResolveSubjectPath(ctx, provider, "Social and Behavioral Sciences > Economics")
+	assert.Error(t, err)
+
+	provider.SubjectsAcceptable = []*PreprintProviderSubject{{TaxonomiesID: []string{"s1"}, IncludeAllChildren: true}}
+	_, err = client.Subjects.ResolveSubjectPath(ctx, provider, "Social and Behavioral Sciences > Psychology")
+	assert.Error(t, err)
+}
+
+func TestValidateSubjectHierarchy(t *testing.T) {
+	provider := &PreprintProvider{
+		ID: "osf",
+		SubjectsAcceptable: []*PreprintProviderSubject{
+			{TaxonomiesID: []string{"a", "b"}, IncludeAllChildren: false},
+			{TaxonomiesID: []string{"c"}, IncludeAllChildren: true},
+		},
+	}
+
+	assert.NoError(t, ValidateSubjectHierarchy(provider, []string{"a", "b"}))
+	assert.NoError(t, ValidateSubjectHierarchy(provider, []string{"c", "c1", "c11"}))
+	assert.Error(t, ValidateSubjectHierarchy(provider, []string{"a", "b", "b1"}))
+	assert.Error(t, ValidateSubjectHierarchy(provider, []string{"d"}))
+	assert.NoError(t, ValidateSubjectHierarchy(&PreprintProvider{}, []string{"d"}))
+}
@@ -0,0 +1,123 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type TokensService service
+
+type TokenLinks struct {
+	Html *string `json:"html"`
+}
+
+// Token is a personal access token of the authenticated user.
+type Token struct {
+	ID string `json:"id"`
+
+	Name string `json:"name"`
+	// RawScopes is the space-separated list of scopes as returned by the API.
+	RawScopes string `json:"scopes"`
+	// TokenID is the secret token itself. It is only returned on creation.
+	TokenID string `json:"token_id"`
+
+	// Scopes are the IDs of the scopes granted to the token, such as "osf.full_read".
+	Scopes []string `json:"-"`
+
+	Links *TokenLinks `json:"-"`
+}
+
+type TokenRequest struct {
+	Name *string `json:"name,omitempty"`
+	// Scopes replaces the scopes granted to the token, if set.
+	Scopes []string `json:"-"`
+}
+
+type tokenAttributes struct {
+	Name   *string `json:"name,omitempty"`
+	Scopes *string `json:"scopes,omitempty"`
+}
+
+func (r *TokenRequest) attributes() *tokenAttributes {
+	attrs := &tokenAttributes{Name: r.Name}
+	if r.Scopes != nil {
+		attrs.Scopes = StringPointer(strings.Join(r.Scopes, " "))
+	}
+	return attrs
+}
+
+type TokensListOptions struct {
+	ListOptions
+}
+
+func transformToken(raw *Data[*Token, *TokenLinks]) (*Token, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	obj.Scopes = strings.Fields(obj.RawScopes)
+	return obj, nil
+}
+
+func (s *TokensService) ListTokens(ctx context.Context, opts *TokensListOptions) ([]*Token, *ManyPayload[*Token, *TokenLinks], error) {
+	return listResources(s.client, ctx, "tokens/", opts, transformToken)
+}
+
+func (s *TokensService) GetTokenByID(ctx context.Context, id string) (*Token, *SinglePayload[*Token, *TokenLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("tokens/%s/", id), transformToken)
+}
+
+// CreateToken creates a personal access token. The secret token is only
+// available as the TokenID of the returned token, so it must be kept then.
+func (s *TokensService) CreateToken(ctx context.Context, input *TokenRequest) (*Token, *SinglePayload[*Token, *TokenLinks], error) {
+	body := &SinglePayload[*tokenAttributes, interface{}]{
+		Data: &Data[*tokenAttributes, interface{}]{
+			Type:       TypeTokens,
+			Attributes: input.attributes(),
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, "tokens/", body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *TokensService) UpdateToken(ctx context.Context, id string, input *TokenRequest) (*Token, *SinglePayload[*Token, *TokenLinks], error) {
+	body := &SinglePayload[*tokenAttributes, interface{}]{
+		Data: &Data[*tokenAttributes, interface{}]{
+			Type:       TypeTokens,
+			ID:         &id,
+			Attributes: input.attributes(),
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("tokens/%s/", id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// DeleteToken deletes a personal access token, which revokes it.
+func (s *TokensService) DeleteToken(ctx context.Context, id string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("tokens/%s/", id), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
@@ -0,0 +1,73 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokensService_CreateToken(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body map[string]map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "tokens", body["data"]["type"])
+		assert.Equal(t, map[string]interface{}{"name": "ci", "scopes": "osf.full_read osf.full_write"}, body["data"]["attributes"])
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"tok1","type":"tokens","attributes":{"name":"ci","scopes":"osf.full_read osf.full_write","token_id":"s3cr3t"}}}`)
+	})
+
+	token, _, err := client.Tokens.CreateToken(context.Background(), &TokenRequest{
+		Name:   StringPointer("ci"),
+		Scopes: []string{"osf.full_read", "osf.full_write"},
+	})
+	if err != nil {
+		t.Fatalf("Tokens.CreateToken returned error: %v", err)
+	}
+	assert.Equal(t, "tok1", token.ID)
+	assert.Equal(t, "s3cr3t", token.TokenID)
+	assert.Equal(t, []string{"osf.full_read", "osf.full_write"}, token.Scopes)
+}
+
+func TestTokensService_DeleteToken(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/tokens/tok1/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := client.Tokens.DeleteToken(context.Background(), "tok1"); err != nil {
+		t.Fatalf("Tokens.DeleteToken returned error: %v", err)
+	}
+}
+
+func TestScopesService_ListScopes(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/scopes/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"data":[
+			{"id":"osf.full_read","type":"scopes","attributes":{"description":"View all information associated with this account."}},
+			{"id":"osf.full_write","type":"scopes","attributes":{"description":"View and edit all information associated with this account."}}
+		],"links":{"meta":{"total":2,"per_page":10}}}`)
+	})
+
+	scopes, _, err := client.Scopes.ListScopes(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Scopes.ListScopes returned error: %v", err)
+	}
+	assert.Len(t, scopes, 2)
+	assert.Equal(t, "osf.full_write", scopes[1].ID)
+}
@@ -0,0 +1,41 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+)
+
+type UsersService service
+
+type User struct {
+	ID string `json:"id"`
+
+	FullName       string  `json:"full_name"`
+	GivenName      string  `json:"given_name"`
+	MiddleNames    string  `json:"middle_names"`
+	FamilyName     string  `json:"family_name"`
+	Suffix         string  `json:"suffix"`
+	DateRegistered *Time   `json:"date_registered"`
+	Active         bool    `json:"active"`
+	Timezone       *string `json:"timezone"`
+	Locale         *string `json:"locale"`
+
+	Links *UserLinks `json:"-"`
+}
+
+type UserLinks struct {
+	Self         *string `json:"self"`
+	Html         *string `json:"html"`
+	ProfileImage *string `json:"profile_image"`
+}
+
+func transformUser(raw *Data[*User, *UserLinks]) (*User, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+// GetUserByID gets a user. Use "me" as id to get the current user.
+func (s *UsersService) GetUserByID(ctx context.Context, id string) (*User, *SinglePayload[*User, *UserLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("users/%s/", id), transformUser)
+}
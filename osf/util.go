@@ -1,5 +1,10 @@
 package osf
 
+import (
+	"net/url"
+	"strings"
+)
+
 func StringPointer(s string) *string {
 	return &s
 }
@@ -7,3 +12,12 @@ func StringPointer(s string) *string {
 func BoolPointer(b bool) *bool {
 	return &b
 }
+
+// appendQuery adds a query parameter to the URL string s.
+func appendQuery(s string, key string, value string) string {
+	sep := "?"
+	if strings.Contains(s, "?") {
+		sep = "&"
+	}
+	return s + sep + url.QueryEscape(key) + "=" + url.QueryEscape(value)
+}
@@ -0,0 +1,144 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type ViewOnlyLinksService service
+
+type ViewOnlyLinkLinks struct {
+	Self *string `json:"self"`
+}
+
+// ViewOnlyLink grants read access to private nodes to anyone knowing its key,
+// e.g. reviewers. See Client.ViewOnlyKey to make requests with it.
+type ViewOnlyLink struct {
+	ID string `json:"id"`
+
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Anonymous   bool   `json:"anonymous"`
+	DateCreated *Time  `json:"date_created"`
+
+	// CreatorID is the ID of the user who created the link.
+	CreatorID string `json:"-"`
+
+	Links *ViewOnlyLinkLinks `json:"-"`
+}
+
+type ViewOnlyLinkRequest struct {
+	Name *string `json:"name,omitempty"`
+	// Anonymous hides the contributors of the nodes from the link users.
+	Anonymous *bool `json:"anonymous,omitempty"`
+
+	// NodeIDs are nodes, typically components, to add to the link on creation
+	// besides the node it is created on.
+	NodeIDs []string `json:"-"`
+}
+
+type ViewOnlyLinksListOptions struct {
+	ListOptions
+}
+
+func transformViewOnlyLink(raw *Data[*ViewOnlyLink, *ViewOnlyLinkLinks]) (*ViewOnlyLink, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["creator"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.CreatorID = *rel.Data.ID
+	}
+	return obj, nil
+}
+
+func (s *ViewOnlyLinksService) ListNodeViewOnlyLinks(ctx context.Context, nodeID string, opts *ViewOnlyLinksListOptions) ([]*ViewOnlyLink, *ManyPayload[*ViewOnlyLink, *ViewOnlyLinkLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("nodes/%s/view_only_links/", nodeID), opts, transformViewOnlyLink)
+}
+
+func (s *ViewOnlyLinksService) GetNodeViewOnlyLink(ctx context.Context, nodeID string, linkID string) (*ViewOnlyLink, *SinglePayload[*ViewOnlyLink, *ViewOnlyLinkLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("nodes/%s/view_only_links/%s/", nodeID, linkID), transformViewOnlyLink)
+}
+
+// CreateNodeViewOnlyLink creates a view-only link on a node, then adds
+// input.NodeIDs to it. If adding the nodes fails, the link exists anyway: it
+// is returned along with the error, so that it can be deleted or completed.
+func (s *ViewOnlyLinksService) CreateNodeViewOnlyLink(ctx context.Context, nodeID string, input *ViewOnlyLinkRequest) (*ViewOnlyLink, *SinglePayload[*ViewOnlyLink, *ViewOnlyLinkLinks], error) {
+	body := &SinglePayload[*ViewOnlyLinkRequest, interface{}]{
+		Data: &Data[*ViewOnlyLinkRequest, interface{}]{
+			Type:       TypeViewOnlyLinks,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("nodes/%s/view_only_links/", nodeID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformViewOnlyLink)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	link := res.TransformedData()
+	if len(input.NodeIDs) > 0 {
+		if err := s.AddViewOnlyLinkNodes(ctx, link.ID, input.NodeIDs...); err != nil {
+			return link, res, err
+		}
+	}
+
+	return link, res, nil
+}
+
+func (s *ViewOnlyLinksService) UpdateNodeViewOnlyLink(ctx context.Context, nodeID string, linkID string, input *ViewOnlyLinkRequest) (*ViewOnlyLink, *SinglePayload[*ViewOnlyLink, *ViewOnlyLinkLinks], error) {
+	body := &SinglePayload[*ViewOnlyLinkRequest, interface{}]{
+		Data: &Data[*ViewOnlyLinkRequest, interface{}]{
+			Type:       TypeViewOnlyLinks,
+			ID:         &linkID,
+			Attributes: input,
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPatch, fmt.Sprintf("nodes/%s/view_only_links/%s/", nodeID, linkID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformViewOnlyLink)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *ViewOnlyLinksService) DeleteNodeViewOnlyLink(ctx context.Context, nodeID string, linkID string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("nodes/%s/view_only_links/%s/", nodeID, linkID), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
+
+// ListViewOnlyLinkNodes lists the nodes a view-only link grants access to.
+func (s *ViewOnlyLinksService) ListViewOnlyLinkNodes(ctx context.Context, linkID string, opts *ListOptions) ([]*Node, *ManyPayload[*Node, *NodeLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("view_only_links/%s/nodes/", linkID), opts, transformNode)
+}
+
+func (s *ViewOnlyLinksService) updateNodes(ctx context.Context, method string, linkID string, nodeIDs []string) error {
+	req, err := s.client.NewRequest(method, fmt.Sprintf("view_only_links/%s/relationships/nodes/", linkID), newRelationshipsPayload(TypeNodes, nodeIDs...))
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
+
+func (s *ViewOnlyLinksService) AddViewOnlyLinkNodes(ctx context.Context, linkID string, nodeIDs ...string) error {
+	return s.updateNodes(ctx, http.MethodPost, linkID, nodeIDs)
+}
+
+func (s *ViewOnlyLinksService) RemoveViewOnlyLinkNodes(ctx context.Context, linkID string, nodeIDs ...string) error {
+	return s.updateNodes(ctx, http.MethodDelete, linkID, nodeIDs)
+}
@@ -0,0 +1,89 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewOnlyLinksService_CreateNodeViewOnlyLink(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/abc12/view_only_links/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body SinglePayload[*ViewOnlyLinkRequest, interface{}]
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, *body.Data.Attributes.Anonymous)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"vol1","type":"view_only_links","attributes":{"key":"secret","name":"Reviewers","anonymous":true}}}`)
+	})
+	mux.HandleFunc("/view_only_links/vol1/relationships/nodes/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body RelationshipsPayload
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "comp1", *body.Data[0].ID)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":[{"id":"comp1","type":"nodes"}]}`)
+	})
+
+	link, _, err := client.ViewOnlyLinks.CreateNodeViewOnlyLink(context.Background(), "abc12", &ViewOnlyLinkRequest{
+		Name:      StringPointer("Reviewers"),
+		Anonymous: BoolPointer(true),
+		NodeIDs:   []string{"comp1"},
+	})
+	if err != nil {
+		t.Fatalf("ViewOnlyLinks.CreateNodeViewOnlyLink returned error: %v", err)
+	}
+	assert.Equal(t, "secret", link.Key)
+}
+
+func TestViewOnlyLinksService_CreateNodeViewOnlyLink_NodesError(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/nodes/abc12/view_only_links/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"vol1","type":"view_only_links","attributes":{"key":"secret","name":"Reviewers"}}}`)
+	})
+	mux.HandleFunc("/view_only_links/vol1/relationships/nodes/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"detail":"The node must be a component of the link node."}]}`)
+	})
+
+	// The link which was created is returned, so that it is not orphaned.
+	link, _, err := client.ViewOnlyLinks.CreateNodeViewOnlyLink(context.Background(), "abc12", &ViewOnlyLinkRequest{
+		Name:    StringPointer("Reviewers"),
+		NodeIDs: []string{"other"},
+	})
+	assert.EqualError(t, err, "The node must be a component of the link node.")
+	if assert.NotNil(t, link) {
+		assert.Equal(t, "vol1", link.ID)
+	}
+}
+
+func TestClient_ViewOnlyKey(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/preprints/xfdsr", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("view_only"))
+		fmt.Fprint(w, `{"data":{"id":"xfdsr","type":"preprints","attributes":{}}}`)
+	})
+
+	client.ViewOnlyKey = "secret"
+	_, _, err := client.Preprints.GetPreprintByID(context.Background(), "xfdsr")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,347 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PreprintChangeType is the kind of a PreprintChange.
+type PreprintChangeType string
+
+const (
+	PreprintCreated         PreprintChangeType = "created"
+	PreprintModified        PreprintChangeType = "modified"
+	PreprintPublished       PreprintChangeType = "published"
+	PreprintWithdrawn       PreprintChangeType = "withdrawn"
+	PreprintStateTransition PreprintChangeType = "state_transition"
+)
+
+// PreprintChange is a change of a preprint noticed by a PreprintWatcher.
+type PreprintChange struct {
+	Type     PreprintChangeType
+	Preprint *Preprint
+
+	// FromState and ToState are the reviews states before and after a PreprintStateTransition.
+	FromState string
+	ToState   string
+}
+
+// preprintSnapshot is what a PreprintWatcher remembers of a preprint to detect its changes.
+type preprintSnapshot struct {
+	DateModified time.Time `json:"date_modified"`
+	ReviewsState string    `json:"reviews_state"`
+	IsPublished  bool      `json:"is_published"`
+	Withdrawn    bool      `json:"withdrawn"`
+}
+
+const defaultWatcherRetention = 30 * 24 * time.Hour
+
+type preprintWatcherCheckpoint struct {
+	Since     time.Time                    `json:"since"`
+	Preprints map[string]*preprintSnapshot `json:"preprints"`
+}
+
+// PreprintWatcher polls ListPreprints for preprints modified since its last
+// poll and turns them into typed changes. Its checkpoint is persisted to a
+// CheckpointStore after every poll, so a restarted watcher neither misses nor
+// repeats changes. The checkpoint remembers the preprints modified within
+// Retention, in order to detect their state transitions. A preprint seen for
+// the first time, or again after Retention, never produces a
+// PreprintStateTransition, since its previous state is unknown.
+type PreprintWatcher struct {
+	client    *Client
+	store     CheckpointStore
+	providers []string
+
+	// Interval is the time between two polls of Watch and Changes. Defaults to 5 minutes.
+	Interval time.Duration
+	// Since is where watching starts when the store holds no checkpoint yet. Defaults to the time of the first poll.
+	Since time.Time
+	// Key is the key of the checkpoint in the store. Defaults to one derived from the providers.
+	Key string
+	// Retention is how long the snapshot of a preprint is kept in the checkpoint
+	// after its last modification. Defaults to 30 days.
+	Retention time.Duration
+}
+
+// NewWatcher returns a PreprintWatcher for the preprints of providers, or of
+// every provider if there are none, whose checkpoint is kept in store.
+func (s *PreprintsService) NewWatcher(store CheckpointStore, providers ...string) *PreprintWatcher {
+	return &PreprintWatcher{
+		client:    s.client,
+		store:     store,
+		providers: providers,
+		Interval:  5 * time.Minute,
+		Retention: defaultWatcherRetention,
+	}
+}
+
+func (w *PreprintWatcher) key() string {
+	if w.Key != "" {
+		return w.Key
+	}
+	if len(w.providers) == 0 {
+		return "preprint-watcher"
+	}
+	return "preprint-watcher-" + strings.Join(w.providers, "-")
+}
+
+func (w *PreprintWatcher) loadCheckpoint(ctx context.Context) (*preprintWatcherCheckpoint, error) {
+	b, err := w.store.Load(ctx, w.key())
+	if err != nil {
+		return nil, err
+	}
+
+	cp := &preprintWatcherCheckpoint{}
+	if b != nil {
+		if err := json.Unmarshal(b, cp); err != nil {
+			return nil, err
+		}
+	} else {
+		cp.Since = w.Since
+		if cp.Since.IsZero() {
+			cp.Since = time.Now().UTC()
+		}
+	}
+	if cp.Preprints == nil {
+		cp.Preprints = map[string]*preprintSnapshot{}
+	}
+	return cp, nil
+}
+
+func (w *PreprintWatcher) saveCheckpoint(ctx context.Context, cp *preprintWatcherCheckpoint) error {
+	b, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return w.store.Save(ctx, w.key(), b)
+}
+
+// poll returns the changes since the checkpoint, along with the checkpoint to save once they are handled.
+func (w *PreprintWatcher) poll(ctx context.Context) ([]*PreprintChange, *preprintWatcherCheckpoint, error) {
+	cp, err := w.loadCheckpoint(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	since := cp.Since
+	opts := &PreprintsListOptions{
+		ListOptions: ListOptions{Page: 1, PerPage: 100, Filter: map[string]string{}},
+		Sort:        "date_modified",
+	}
+	if len(w.providers) > 0 {
+		opts.Filter["provider"] = strings.Join(w.providers, ",")
+	}
+
+	// Pages are fetched by keyset rather than by number: each query starts at
+	// the latest modification seen so far. Otherwise a preprint modified
+	// during the poll would move to the end of the listing and shift the
+	// following ones to pages already fetched, where they would be missed.
+	var preprints []*Preprint
+	seen := map[string]time.Time{}
+	after := since
+	for {
+		from := after
+		opts.DateModifiedSince = &from
+		page, res, err := w.client.Preprints.ListPreprints(ctx, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		last := after
+		for _, preprint := range page {
+			modified := preprintModified(preprint)
+			if prev, ok := seen[preprint.ID]; ok && !modified.After(prev) {
+				continue
+			}
+			seen[preprint.ID] = modified
+			preprints = append(preprints, preprint)
+			if modified.After(last) {
+				last = modified
+			}
+		}
+
+		perPage := opts.PerPage
+		if res.PaginationMeta != nil && res.PaginationMeta.PerPage > 0 {
+			perPage = res.PaginationMeta.PerPage
+		}
+		if len(page) < perPage || res.PaginationMeta == nil || opts.Page*perPage >= res.PaginationMeta.Total {
+			break
+		}
+		if last.After(after) {
+			after = last
+			opts.Page = 1
+		} else {
+			// A whole page modified at the same time, which the next page of the same query follows.
+			opts.Page++
+		}
+	}
+
+	sort.SliceStable(preprints, func(i, j int) bool {
+		return preprintModified(preprints[i]).Before(preprintModified(preprints[j]))
+	})
+
+	var changes []*PreprintChange
+	for _, preprint := range preprints {
+		modified := preprintModified(preprint)
+		prev := cp.Preprints[preprint.ID]
+		if prev != nil && !modified.After(prev.DateModified) {
+			// Already seen, e.g. returned again because its date equals the checkpoint.
+			continue
+		}
+
+		cur := &preprintSnapshot{
+			DateModified: modified,
+			ReviewsState: preprint.ReviewsState,
+			IsPublished:  preprint.IsPublished,
+			Withdrawn:    preprint.DateWithdrawn != nil,
+		}
+		changes = append(changes, diffPreprint(preprint, prev, cur, since)...)
+
+		cp.Preprints[preprint.ID] = cur
+		if modified.After(cp.Since) {
+			cp.Since = modified
+		}
+	}
+
+	// Forget the preprints which were not modified for long, so that the
+	// checkpoint does not grow with every preprint ever seen.
+	retention := w.Retention
+	if retention <= 0 {
+		retention = defaultWatcherRetention
+	}
+	for id, snapshot := range cp.Preprints {
+		if snapshot.DateModified.Before(cp.Since.Add(-retention)) {
+			delete(cp.Preprints, id)
+		}
+	}
+
+	return changes, cp, nil
+}
+
+func preprintModified(preprint *Preprint) time.Time {
+	if preprint.DateModified != nil {
+		return preprint.DateModified.Time
+	}
+	if preprint.DateCreated != nil {
+		return preprint.DateCreated.Time
+	}
+	return time.Time{}
+}
+
+// diffPreprint returns the changes between the previous snapshot of a preprint,
+// which is nil if the preprint is not in the checkpoint, and its current one.
+// Without previous snapshot, publications and withdrawals are told from their
+// dates.
+func diffPreprint(preprint *Preprint, prev *preprintSnapshot, cur *preprintSnapshot, since time.Time) []*PreprintChange {
+	var changes []*PreprintChange
+	add := func(typ PreprintChangeType) {
+		changes = append(changes, &PreprintChange{Type: typ, Preprint: preprint})
+	}
+
+	if prev == nil {
+		created := preprint.DateCreated != nil && !preprint.DateCreated.Before(since)
+		if created {
+			add(PreprintCreated)
+		}
+		prev = &preprintSnapshot{
+			IsPublished: (preprint.DatePublished != nil && preprint.DatePublished.Before(since)) || (preprint.DatePublished == nil && !created),
+			Withdrawn:   preprint.DateWithdrawn != nil && preprint.DateWithdrawn.Before(since),
+		}
+	} else if prev.ReviewsState != cur.ReviewsState {
+		changes = append(changes, &PreprintChange{
+			Type:      PreprintStateTransition,
+			Preprint:  preprint,
+			FromState: prev.ReviewsState,
+			ToState:   cur.ReviewsState,
+		})
+	}
+
+	if cur.IsPublished && !prev.IsPublished {
+		add(PreprintPublished)
+	}
+	if cur.Withdrawn && !prev.Withdrawn {
+		add(PreprintWithdrawn)
+	}
+	if len(changes) == 0 {
+		add(PreprintModified)
+	}
+
+	return changes
+}
+
+// Poll returns the changes since the previous poll and saves the checkpoint.
+func (w *PreprintWatcher) Poll(ctx context.Context) ([]*PreprintChange, error) {
+	changes, cp, err := w.poll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.saveCheckpoint(ctx, cp); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// Watch polls every Interval and calls fn for each change, until ctx is done
+// or fn returns an error. The checkpoint is only saved once fn has handled all
+// the changes of a poll, so changes are delivered at least once.
+func (w *PreprintWatcher) Watch(ctx context.Context, fn func(change *PreprintChange) error) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		changes, cp, err := w.poll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			if err := fn(change); err != nil {
+				return err
+			}
+		}
+		if err := w.saveCheckpoint(ctx, cp); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Changes runs Watch in a goroutine and delivers the changes on the returned
+// channel. Both channels are closed when watching stops; the error which
+// stopped it, if any other than the cancellation of ctx, is sent on the error
+// channel first.
+func (w *PreprintWatcher) Changes(ctx context.Context) (<-chan *PreprintChange, <-chan error) {
+	changes := make(chan *PreprintChange)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(changes)
+
+		err := w.Watch(ctx, func(change *PreprintChange) error {
+			select {
+			case changes <- change:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			errs <- err
+		}
+	}()
+
+	return changes, errs
+}
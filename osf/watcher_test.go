@@ -0,0 +1,181 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreprintWatcher_Poll(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	responses := []string{
+		`{"data":[
+			{"id":"p1","type":"preprints","attributes":{"date_created":"2022-05-02T00:00:00","date_modified":"2022-05-02T00:00:00","is_published":true,"reviews_state":"accepted"}},
+			{"id":"p2","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_modified":"2022-05-03T00:00:00","reviews_state":"pending"}}
+		],"links":{"meta":{"total":2,"per_page":100}}}`,
+		`{"data":[
+			{"id":"p2","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_modified":"2022-05-03T00:00:00","reviews_state":"pending"}},
+			{"id":"p2","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_modified":"2022-05-04T00:00:00","reviews_state":"rejected","date_withdrawn":"2022-05-04T00:00:00"}}
+		],"links":{"meta":{"total":2,"per_page":100}}}`,
+	}
+	calls := 0
+	mux.HandleFunc("/preprints", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "psyarxiv,socarxiv", r.URL.Query().Get("filter[provider]"))
+		if calls == 1 {
+			assert.Equal(t, "2022-05-03T00:00:00Z", r.URL.Query().Get("filter[date_modified][gte]"))
+		}
+		fmt.Fprint(w, responses[calls])
+		calls++
+	})
+
+	store := NewMemoryCheckpointStore()
+	ctx := context.Background()
+
+	watcher := client.Preprints.NewWatcher(store, "psyarxiv", "socarxiv")
+	watcher.Since = time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	changes, err := watcher.Poll(ctx)
+	if err != nil {
+		t.Fatalf("PreprintWatcher.Poll returned error: %v", err)
+	}
+	assert.Equal(t, []PreprintChangeType{PreprintCreated, PreprintPublished, PreprintModified}, changeTypes(changes))
+
+	// A new watcher resumes from the checkpoint of the store.
+	watcher = client.Preprints.NewWatcher(store, "psyarxiv", "socarxiv")
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatalf("PreprintWatcher.Poll returned error: %v", err)
+	}
+	assert.Equal(t, []PreprintChangeType{PreprintStateTransition, PreprintWithdrawn}, changeTypes(changes))
+	assert.Equal(t, "pending", changes[0].FromState)
+	assert.Equal(t, "rejected", changes[0].ToState)
+}
+
+func TestPreprintWatcher_Poll_retention(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	responses := []string{
+		`{"data":[
+			{"id":"p1","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_published":"2021-01-02T00:00:00","date_modified":"2022-01-01T00:00:00","is_published":true,"reviews_state":"accepted"}},
+			{"id":"p2","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_published":"2022-03-01T00:00:00","date_modified":"2022-03-01T00:00:00","is_published":true,"reviews_state":"accepted"}}
+		],"links":{"meta":{"total":2,"per_page":100}}}`,
+		`{"data":[
+			{"id":"p1","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_published":"2021-01-02T00:00:00","date_modified":"2022-03-02T00:00:00","is_published":true,"reviews_state":"withdrawn"}}
+		],"links":{"meta":{"total":1,"per_page":100}}}`,
+	}
+	calls := 0
+	mux.HandleFunc("/preprints", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, responses[calls])
+		calls++
+	})
+
+	store := NewMemoryCheckpointStore()
+	ctx := context.Background()
+	watcher := client.Preprints.NewWatcher(store)
+	watcher.Since = time.Date(2021, time.December, 1, 0, 0, 0, 0, time.UTC)
+	watcher.Retention = 30 * 24 * time.Hour
+
+	// p1 was published before Since, p2 after.
+	changes, err := watcher.Poll(ctx)
+	if err != nil {
+		t.Fatalf("PreprintWatcher.Poll returned error: %v", err)
+	}
+	assert.Equal(t, []PreprintChangeType{PreprintModified, PreprintPublished}, changeTypes(changes))
+
+	// p1 was not modified within the retention of the last change, so it is forgotten.
+	b, _ := store.Load(ctx, "preprint-watcher")
+	assert.NotContains(t, string(b), `"p1"`)
+	assert.Contains(t, string(b), `"p2"`)
+
+	// Its previous state is unknown, so its transition is not noticed.
+	changes, err = watcher.Poll(ctx)
+	if err != nil {
+		t.Fatalf("PreprintWatcher.Poll returned error: %v", err)
+	}
+	assert.Equal(t, []PreprintChangeType{PreprintModified}, changeTypes(changes))
+}
+
+func TestPreprintWatcher_Poll_listingChanges(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	modified := map[string]string{
+		"p1": "2022-05-01T00:00:00Z",
+		"p2": "2022-05-02T00:00:00Z",
+		"p3": "2022-05-03T00:00:00Z",
+		"p4": "2022-05-04T00:00:00Z",
+	}
+	requests := 0
+	mux.HandleFunc("/preprints", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			// p1 is modified between the first page and the next ones.
+			modified["p1"] = "2022-05-05T00:00:00Z"
+		}
+
+		since, _ := time.Parse(time.RFC3339, r.URL.Query().Get("filter[date_modified][gte]"))
+		var ids []string
+		for id, date := range modified {
+			if d, _ := time.Parse(time.RFC3339, date); !d.Before(since) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return modified[ids[i]] < modified[ids[j]] })
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		var data []string
+		for i := (page - 1) * 2; i < page*2 && i < len(ids); i++ {
+			data = append(data, fmt.Sprintf(`{"id":"%s","type":"preprints","attributes":{"date_created":"2021-01-01T00:00:00","date_modified":"%s"}}`, ids[i], modified[ids[i]]))
+		}
+		fmt.Fprintf(w, `{"data":[%s],"links":{"meta":{"total":%d,"per_page":2}}}`, strings.Join(data, ","), len(ids))
+	})
+
+	watcher := client.Preprints.NewWatcher(NewMemoryCheckpointStore())
+	watcher.Since = time.Date(2022, time.May, 1, 0, 0, 0, 0, time.UTC)
+
+	changes, err := watcher.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("PreprintWatcher.Poll returned error: %v", err)
+	}
+
+	var ids []string
+	for _, change := range changes {
+		ids = append(ids, change.Preprint.ID)
+	}
+	assert.Equal(t, []string{"p1", "p2", "p3", "p4", "p1"}, ids)
+}
+
+func changeTypes(changes []*PreprintChange) []PreprintChangeType {
+	types := make([]PreprintChangeType, 0, len(changes))
+	for _, change := range changes {
+		types = append(types, change.Type)
+	}
+	return types
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := NewFileCheckpointStore(t.TempDir())
+	ctx := context.Background()
+
+	b, err := store.Load(ctx, "watcher")
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+
+	assert.NoError(t, store.Save(ctx, "watcher", []byte(`{"since":"2022-05-01T00:00:00Z"}`)))
+	assert.NoError(t, store.Save(ctx, "watcher", []byte(`{"since":"2022-05-02T00:00:00Z"}`)))
+
+	b, err = store.Load(ctx, "watcher")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"since":"2022-05-02T00:00:00Z"}`, string(b))
+}
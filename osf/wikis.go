@@ -0,0 +1,180 @@
+package osf
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+type WikisService service
+
+type WikiLinks struct {
+	Self     *string `json:"self"`
+	Info     *string `json:"info"`
+	Download *string `json:"download"`
+}
+
+type WikiExtra struct {
+	Version int `json:"version"`
+}
+
+type Wiki struct {
+	ID string `json:"id"`
+
+	Name                  string     `json:"name"`
+	Kind                  string     `json:"kind"`
+	Size                  int64      `json:"size"`
+	Path                  string     `json:"path"`
+	MaterializedPath      string     `json:"materialized_path"`
+	DateModified          *Time      `json:"date_modified"`
+	ContentType           string     `json:"content_type"`
+	CurrentUserCanComment bool       `json:"current_user_can_comment"`
+	Extra                 *WikiExtra `json:"extra"`
+
+	Links *WikiLinks `json:"-"`
+}
+
+type WikiVersionLinks struct {
+	Self     *string `json:"self"`
+	Download *string `json:"download"`
+}
+
+// WikiVersion is a historical version of a wiki page. Its ID is the version number.
+type WikiVersion struct {
+	ID string `json:"id"`
+
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	DateCreated *Time  `json:"date_created"`
+
+	// UserID is the ID of the user who wrote this version.
+	UserID string `json:"-"`
+
+	Links *WikiVersionLinks `json:"-"`
+}
+
+type wikiRequest struct {
+	Name    string `json:"name,omitempty"`
+	Content string `json:"content"`
+}
+
+type WikisListOptions struct {
+	ListOptions
+}
+
+func transformWiki(raw *Data[*Wiki, *WikiLinks]) (*Wiki, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	return obj, nil
+}
+
+func transformWikiVersion(raw *Data[*WikiVersion, *WikiVersionLinks]) (*WikiVersion, error) {
+	obj := raw.Attributes
+	obj.Links = raw.Links
+	if rel, ok := raw.Relationships["user"]; ok && rel.Data != nil && rel.Data.ID != nil {
+		obj.UserID = *rel.Data.ID
+	}
+	return obj, nil
+}
+
+// ListNodeWikis lists the wiki pages of a node. Filter on "name" through opts.Filter.
+func (s *WikisService) ListNodeWikis(ctx context.Context, nodeID string, opts *WikisListOptions) ([]*Wiki, *ManyPayload[*Wiki, *WikiLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("nodes/%s/wikis/", nodeID), opts, transformWiki)
+}
+
+func (s *WikisService) GetWikiByID(ctx context.Context, id string) (*Wiki, *SinglePayload[*Wiki, *WikiLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("wikis/%s/", id), transformWiki)
+}
+
+func (s *WikisService) getContent(ctx context.Context, u string) (io.ReadCloser, error) {
+	req, err := s.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return doRaw(s.client, ctx, req)
+}
+
+// GetWikiContent returns the raw content of the current version of a wiki page.
+// The caller must close the returned reader.
+func (s *WikisService) GetWikiContent(ctx context.Context, id string) (io.ReadCloser, error) {
+	return s.getContent(ctx, fmt.Sprintf("wikis/%s/content/", id))
+}
+
+// CreateWiki creates a wiki page on a node, with its first version read from content.
+func (s *WikisService) CreateWiki(ctx context.Context, nodeID string, name string, content io.Reader) (*Wiki, *SinglePayload[*Wiki, *WikiLinks], error) {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := &SinglePayload[*wikiRequest, interface{}]{
+		Data: &Data[*wikiRequest, interface{}]{
+			Type:       TypeWikis,
+			Attributes: &wikiRequest{Name: name, Content: string(b)},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("nodes/%s/wikis/", nodeID), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformWiki)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+// CreateWikiVersion uploads a new version of a wiki page, read from content.
+func (s *WikisService) CreateWikiVersion(ctx context.Context, id string, content io.Reader) (*WikiVersion, *SinglePayload[*WikiVersion, *WikiVersionLinks], error) {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body := &SinglePayload[*wikiRequest, interface{}]{
+		Data: &Data[*wikiRequest, interface{}]{
+			Type:       TypeWikiVersions,
+			Attributes: &wikiRequest{Content: string(b)},
+		},
+	}
+
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("wikis/%s/versions/", id), body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := doSingle(s.client, ctx, req, transformWikiVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.TransformedData(), res, nil
+}
+
+func (s *WikisService) ListWikiVersions(ctx context.Context, id string, opts *ListOptions) ([]*WikiVersion, *ManyPayload[*WikiVersion, *WikiVersionLinks], error) {
+	return listResources(s.client, ctx, fmt.Sprintf("wikis/%s/versions/", id), opts, transformWikiVersion)
+}
+
+func (s *WikisService) GetWikiVersion(ctx context.Context, id string, versionID string) (*WikiVersion, *SinglePayload[*WikiVersion, *WikiVersionLinks], error) {
+	return getResource(s.client, ctx, fmt.Sprintf("wikis/%s/versions/%s/", id, versionID), transformWikiVersion)
+}
+
+// GetWikiVersionContent returns the raw content of a historical version of a wiki page.
+// The caller must close the returned reader.
+func (s *WikisService) GetWikiVersionContent(ctx context.Context, id string, versionID string) (io.ReadCloser, error) {
+	return s.getContent(ctx, fmt.Sprintf("wikis/%s/versions/%s/content/", id, versionID))
+}
+
+func (s *WikisService) DeleteWiki(ctx context.Context, id string) error {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("wikis/%s/", id), nil)
+	if err != nil {
+		return err
+	}
+
+	return doNoContent(s.client, ctx, req)
+}
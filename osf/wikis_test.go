@@ -0,0 +1,61 @@
+package osf
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWikisService_VersionsAndContent(t *testing.T) {
+	client, mux, teardown := setupServer()
+	defer teardown()
+
+	mux.HandleFunc("/wikis/w1/versions/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var body SinglePayload[*wikiRequest, interface{}]
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, TypeWikiVersions, body.Data.Type)
+		assert.Equal(t, "# Methods\n", body.Data.Attributes.Content)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"data":{"id":"2","type":"wiki-versions","attributes":{"size":10},
+			"relationships":{"user":{"data":{"id":"u1","type":"users"}}}}}`)
+	})
+	mux.HandleFunc("/wikis/w1/content/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "# Methods\n")
+	})
+	mux.HandleFunc("/wikis/gone/content/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"detail":"Not found."}]}`)
+	})
+
+	ctx := context.Background()
+	version, _, err := client.Wikis.CreateWikiVersion(ctx, "w1", strings.NewReader("# Methods\n"))
+	if err != nil {
+		t.Fatalf("Wikis.CreateWikiVersion returned error: %v", err)
+	}
+	assert.Equal(t, "2", version.ID)
+	assert.Equal(t, "u1", version.UserID)
+
+	content, err := client.Wikis.GetWikiContent(ctx, "w1")
+	if err != nil {
+		t.Fatalf("Wikis.GetWikiContent returned error: %v", err)
+	}
+	defer content.Close()
+	b, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "# Methods\n", string(b))
+
+	_, err = client.Wikis.GetWikiContent(ctx, "gone")
+	assert.EqualError(t, err, "Not found.")
+}
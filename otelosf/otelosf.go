@@ -0,0 +1,277 @@
+/*
+Package otelosf instruments go-osf clients with OpenTelemetry.
+
+It creates a client span for every request, named after the service method
+making it, such as "Preprints.ListPreprints" or "Files.DownloadFile", and
+records metrics of requests, latencies, bytes transferred and retries:
+
+	client := osf.NewClient(tc)
+	if err := otelosf.Instrument(client); err != nil {
+		log.Fatal(err)
+	}
+
+The global tracer and meter providers are used unless WithTracerProvider and
+WithMeterProvider are given. This package is a separate module, so that the
+client does not depend on OpenTelemetry.
+*/
+package otelosf
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/joshuabezaleel/go-osf/otelosf"
+
+// Attributes set on spans and metrics, besides the HTTP semantic conventions.
+const (
+	OperationKey    = attribute.Key("osf.operation")
+	ResourceTypeKey = attribute.Key("osf.resource.type")
+	ResourceIDKey   = attribute.Key("osf.resource.id")
+	PageKey         = attribute.Key("osf.page")
+	RetriesKey      = attribute.Key("osf.retries")
+	ErrorsKey       = attribute.Key("osf.errors")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation.
+type Option func(c *config)
+
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators injecting the trace context into the
+// request headers. Defaults to the global ones.
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+type middleware struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+
+	requests      metric.Int64Counter
+	duration      metric.Float64Histogram
+	requestBytes  metric.Int64Counter
+	responseBytes metric.Int64Counter
+	retries       metric.Int64Counter
+}
+
+// NewMiddleware returns an osf.Middleware tracing and measuring requests.
+func NewMiddleware(opts ...Option) (osf.Middleware, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	m := &middleware{
+		tracer:      c.tracerProvider.Tracer(instrumentationName),
+		propagators: c.propagators,
+	}
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	var err error
+	if m.requests, err = meter.Int64Counter("osf.client.requests",
+		metric.WithDescription("Number of requests to OSF."),
+		metric.WithUnit("{request}")); err != nil {
+		return nil, err
+	}
+	if m.duration, err = meter.Float64Histogram("osf.client.duration",
+		metric.WithDescription("Duration of requests to OSF, retries included."),
+		metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if m.requestBytes, err = meter.Int64Counter("osf.client.request.body.size",
+		metric.WithDescription("Bytes sent to OSF in request bodies."),
+		metric.WithUnit("By")); err != nil {
+		return nil, err
+	}
+	if m.responseBytes, err = meter.Int64Counter("osf.client.response.body.size",
+		metric.WithDescription("Bytes received from OSF in response bodies."),
+		metric.WithUnit("By")); err != nil {
+		return nil, err
+	}
+	if m.retries, err = meter.Int64Counter("osf.client.retries",
+		metric.WithDescription("Number of retries of requests to OSF."),
+		metric.WithUnit("{retry}")); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Instrument adds the middleware returned by NewMiddleware to client, as its outermost one.
+func Instrument(client *osf.Client, opts ...Option) error {
+	m, err := NewMiddleware(opts...)
+	if err != nil {
+		return err
+	}
+	client.Middlewares = append([]osf.Middleware{m}, client.Middlewares...)
+	return nil
+}
+
+func (m *middleware) Wrap(next osf.RequestHandler) osf.RequestHandler {
+	return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+		operation := osf.RequestOperation(ctx)
+		name := operation
+		if name == "" {
+			name = "OSF " + req.Method
+		}
+
+		attrs := requestAttributes(operation, req)
+		ctx, span := m.tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+			trace.WithAttributes(semconv.URLFull(osf.RedactURL(req.URL))),
+		)
+		m.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		start := time.Now()
+		resp, err := next(ctx, req)
+		duration := time.Since(start)
+
+		if resp != nil {
+			attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+		}
+		retries := osf.RequestAttempts(ctx) - 1
+		if retries > 0 {
+			span.SetAttributes(RetriesKey.Int(retries))
+			m.retries.Add(ctx, int64(retries), metric.WithAttributes(attrs...))
+		}
+		span.SetAttributes(attrs...)
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if errResp, ok := err.(*osf.ErrorResponse); ok && len(errResp.Errors) > 0 {
+				details := make([]string, 0, len(errResp.Errors))
+				for _, e := range errResp.Errors {
+					details = append(details, e.Error())
+				}
+				span.SetAttributes(ErrorsKey.StringSlice(details))
+			}
+		}
+
+		set := metric.WithAttributes(attrs...)
+		m.requests.Add(ctx, 1, set)
+		m.duration.Record(ctx, duration.Seconds(), set)
+		if req.ContentLength > 0 {
+			m.requestBytes.Add(ctx, req.ContentLength, set)
+		}
+
+		if resp == nil || err != nil {
+			span.End()
+			return resp, err
+		}
+		// The body may be streamed, e.g. file downloads, so the span ends once it is closed.
+		resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+			m.responseBytes.Add(ctx, n, set)
+			span.End()
+		}}
+		return resp, nil
+	}
+}
+
+// requestAttributes describes a request to the API or to WaterButler.
+func requestAttributes(operation string, req *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if operation != "" {
+		attrs = append(attrs, OperationKey.String(operation))
+	}
+
+	typ, id := resource(req.URL)
+	if typ != "" {
+		attrs = append(attrs, ResourceTypeKey.String(typ))
+	}
+	if id != "" {
+		attrs = append(attrs, ResourceIDKey.String(id))
+	}
+	if page, err := strconv.Atoi(req.URL.Query().Get("page[number]")); err == nil {
+		attrs = append(attrs, PageKey.Int(page))
+	}
+	return attrs
+}
+
+// resource returns the type and ID of the resource of an API URL such as
+// /v2/preprints/{id}/files/, or of a WaterButler URL such as /v1/resources/{id}/...
+func resource(u *url.URL) (string, string) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, segment := range segments {
+		switch segment {
+		case "v2":
+			rest := segments[i+1:]
+			if len(rest) == 0 || rest[0] == "" {
+				return "", ""
+			}
+			if len(rest) == 1 {
+				return rest[0], ""
+			}
+			return rest[0], rest[1]
+		case "v1":
+			if len(segments) > i+2 && segments[i+1] == "resources" {
+				return "files", segments[i+2]
+			}
+		}
+	}
+	return "", ""
+}
+
+// countingBody counts the bytes read from a response body, and reports them when closed.
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	closed  bool
+	onClose func(n int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	if !b.closed {
+		b.closed = true
+		b.onClose(b.n)
+	}
+	return err
+}
@@ -0,0 +1,157 @@
+package otelosf
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/joshuabezaleel/go-osf/osf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T) (*osf.Client, *http.ServeMux, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := osf.NewClientWithOptions(
+		osf.WithBaseURL(server.URL+"/v2/"),
+		osf.WithRetryPolicy(&osf.RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	err = Instrument(client,
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("Instrument returned error: %v", err)
+	}
+
+	return client, mux, spans, reader
+}
+
+func attributes(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestInstrument_Spans(t *testing.T) {
+	client, mux, spans, _ := setup(t)
+
+	mux.HandleFunc("/v2/preprints/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":"xfdsr","type":"preprints","attributes":{"title":"A"}}],"links":{"meta":{"total":1,"per_page":10}}}`)
+	})
+	attempts := 0
+	mux.HandleFunc("/v2/preprints/xfdsr/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"errors":[{"detail":"Not found."}]}`)
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Preprints.ListPreprints(ctx, &osf.PreprintsListOptions{ListOptions: osf.ListOptions{Page: 2}})
+	if err != nil {
+		t.Fatalf("Preprints.ListPreprints returned error: %v", err)
+	}
+	_, _, err = client.Preprints.GetPreprintByID(ctx, "xfdsr")
+	if err == nil {
+		t.Fatal("Preprints.GetPreprintByID returned no error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("got %d spans, want 2", len(ended))
+	}
+
+	list := ended[0]
+	if list.Name() != "Preprints.ListPreprints" {
+		t.Errorf("span name = %q, want Preprints.ListPreprints", list.Name())
+	}
+	attrs := attributes(list.Attributes())
+	if got := attrs[ResourceTypeKey].AsString(); got != "preprints" {
+		t.Errorf("resource type = %q, want preprints", got)
+	}
+	if got := attrs[PageKey].AsInt64(); got != 2 {
+		t.Errorf("page = %d, want 2", got)
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusOK {
+		t.Errorf("status = %d, want 200", got)
+	}
+
+	get := ended[1]
+	if get.Name() != "Preprints.GetPreprintByID" {
+		t.Errorf("span name = %q, want Preprints.GetPreprintByID", get.Name())
+	}
+	if get.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", get.Status().Code)
+	}
+	attrs = attributes(get.Attributes())
+	if got := attrs[ResourceIDKey].AsString(); got != "xfdsr" {
+		t.Errorf("resource ID = %q, want xfdsr", got)
+	}
+	if got := attrs[RetriesKey].AsInt64(); got != 1 {
+		t.Errorf("retries = %d, want 1", got)
+	}
+	if got := attrs[ErrorsKey].AsStringSlice(); len(got) != 1 || got[0] != "Not found." {
+		t.Errorf("errors = %v, want [Not found.]", got)
+	}
+}
+
+func TestInstrument_Metrics(t *testing.T) {
+	client, mux, _, reader := setup(t)
+
+	mux.HandleFunc("/v2/licenses/l1/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"id":"l1","type":"licenses","attributes":{"name":"CC-By"}}}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Licenses.GetLicenseByID(context.Background(), "l1"); err != nil {
+			t.Fatalf("Licenses.GetLicenseByID returned error: %v", err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]interface{}{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	requests, ok := got["osf.client.requests"].(metricdata.Sum[int64])
+	if !ok || len(requests.DataPoints) != 1 || requests.DataPoints[0].Value != 2 {
+		t.Errorf("osf.client.requests = %+v, want 2 requests", got["osf.client.requests"])
+	}
+	duration, ok := got["osf.client.duration"].(metricdata.Histogram[float64])
+	if !ok || len(duration.DataPoints) != 1 || duration.DataPoints[0].Count != 2 {
+		t.Errorf("osf.client.duration = %+v, want 2 observations", got["osf.client.duration"])
+	}
+	size, ok := got["osf.client.response.body.size"].(metricdata.Sum[int64])
+	if !ok || len(size.DataPoints) != 1 || size.DataPoints[0].Value == 0 {
+		t.Errorf("osf.client.response.body.size = %+v, want bytes", got["osf.client.response.body.size"])
+	}
+}